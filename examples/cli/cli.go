@@ -28,7 +28,7 @@ func Run() {
 		log.Fatal(err)
 	}
 
-	spotify := api.NewSpotifyClient(ctx, token)
+	spotify := api.NewSpotifyClient(ctx, api.WithTokenSource(term.TokenSource(ctx, token)))
 	track, err := spotify.GetTrack("4PTG3Z6ehGkBFwjybzWkR8")
 	if err != nil {
 		log.Fatal(err)
@@ -0,0 +1,76 @@
+// Package redact replaces the credentials in the requests and the responses,
+// so they are neither logged by the Spotify client nor recorded to the cassettes.
+package redact
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Value is put in place of the credentials.
+const Value = "REDACTED"
+
+// Names of the params, headers and body fields, which carry the credentials.
+var sensitiveNames = map[string]bool{
+	"authorization":       true,
+	"proxy-authorization": true,
+	"cookie":              true,
+	"set-cookie":          true,
+	"code":                true,
+	"code_verifier":       true,
+	"access_token":        true,
+	"refresh_token":       true,
+	"id_token":            true,
+	"client_secret":       true,
+	"token":               true,
+}
+
+var (
+	// sensitiveJSON matches the string fields of the JSON body, which carry the credentials.
+	sensitiveJSON = regexp.MustCompile(
+		`"(code|code_verifier|access_token|refresh_token|id_token|client_secret|token)"(\s*:\s*)"[^"]*"`,
+	)
+	// sensitiveForm matches the fields of the form-encoded body, which carry the credentials.
+	sensitiveForm = regexp.MustCompile(
+		`(^|&)(code|code_verifier|access_token|refresh_token|id_token|client_secret|token)=[^&]*`,
+	)
+)
+
+// Sensitive reports whether the param, header or body field with the given name carries the credentials.
+func Sensitive(name string) bool {
+	return sensitiveNames[strings.ToLower(name)]
+}
+
+// Query returns the copy of the query params, with the credentials replaced.
+func Query(query url.Values) url.Values {
+	result := url.Values{}
+	for k, v := range query {
+		if Sensitive(k) {
+			v = []string{Value}
+		}
+		result[k] = v
+	}
+	return result
+}
+
+// Header returns the copy of the headers, with the credentials replaced.
+func Header(header http.Header) http.Header {
+	result := http.Header{}
+	for k, v := range header {
+		if Sensitive(k) {
+			v = []string{Value}
+		}
+		result[k] = v
+	}
+	return result
+}
+
+// Body returns the body as the string, with the credentials replaced.
+// Both JSON and form-encoded bodies are supported.
+func Body(body []byte) string {
+	body = sensitiveJSON.ReplaceAll(body, []byte(`"$1"$2"`+Value+`"`))
+	body = sensitiveForm.ReplaceAll(body, []byte(`$1$2=`+Value))
+	return string(body)
+}
@@ -0,0 +1,40 @@
+package redact
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestBody(t *testing.T) {
+	body := Body([]byte(`grant_type=authorization_code&code=abc&redirect_uri=x&refresh_token=def`))
+	if body != "grant_type=authorization_code&code=REDACTED&redirect_uri=x&refresh_token=REDACTED" {
+		t.Errorf("Unexpected form body: %s", body)
+	}
+
+	body = Body([]byte(`{"token": "abc", "name": "code"}`))
+	if body != `{"token": "REDACTED", "name": "code"}` {
+		t.Errorf("Unexpected JSON body: %s", body)
+	}
+
+	body = Body([]byte(`{"access_token":"abc","token_type":"Bearer"}`))
+	if body != `{"access_token":"REDACTED","token_type":"Bearer"}` {
+		t.Errorf("Unexpected JSON body: %s", body)
+	}
+}
+
+func TestQueryAndHeader(t *testing.T) {
+	query := url.Values{"code": {"abc"}, "market": {"ES"}}
+	params := Query(query)
+	if params.Get("code") != Value || params.Get("market") != "ES" {
+		t.Errorf("Unexpected params: %v", params)
+	}
+	if query.Get("code") != "abc" {
+		t.Errorf("Expected original params to be kept, got %v", query)
+	}
+
+	header := Header(http.Header{"Authorization": {"Bearer abc"}, "Set-Cookie": {"a=b"}, "Accept": {"*/*"}})
+	if header.Get("Authorization") != Value || header.Get("Set-Cookie") != Value || header.Get("Accept") != "*/*" {
+		t.Errorf("Unexpected header: %v", header)
+	}
+}
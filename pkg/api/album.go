@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"strings"
 )
@@ -78,8 +79,13 @@ type SavedAlbum struct {
 //
 // Params: Market.
 func (s *Spotify) GetAlbum(id string, params ...Param) (*FullAlbum, error) {
+	return s.GetAlbumCtx(context.Background(), id, params...)
+}
+
+// GetAlbumCtx is the same as GetAlbum, but it sends the request with the given context.
+func (s *Spotify) GetAlbumCtx(ctx context.Context, id string, params ...Param) (*FullAlbum, error) {
 	album := &FullAlbum{}
-	err := s.Get(album, fmt.Sprintf("/albums/%s", id), params...)
+	err := s.GetCtx(ctx, album, fmt.Sprintf("/albums/%s", id), params...)
 	return album, err
 }
 
@@ -87,11 +93,22 @@ func (s *Spotify) GetAlbum(id string, params ...Param) (*FullAlbum, error) {
 //
 // Params: Market.
 func (s *Spotify) GetAlbums(ids []string, params ...Param) ([]*FullAlbum, error) {
-	var w struct {
-		Albums []*FullAlbum `json:"albums"`
-	}
-	err := s.Get(&w, fmt.Sprintf("/albums?ids=%s", strings.Join(ids, ",")), params...)
-	return w.Albums, err
+	return s.GetAlbumsCtx(context.Background(), ids, params...)
+}
+
+// GetAlbumsCtx is the same as GetAlbums, but it sends the request with the given context.
+func (s *Spotify) GetAlbumsCtx(
+	ctx context.Context,
+	ids []string,
+	params ...Param,
+) ([]*FullAlbum, error) {
+	return batchIds(ctx, s, ids, maxAlbumIds, func(ctx context.Context, ids []string) ([]*FullAlbum, error) {
+		var w struct {
+			Albums []*FullAlbum `json:"albums"`
+		}
+		err := s.GetCtx(ctx, &w, fmt.Sprintf("/albums?ids=%s", strings.Join(ids, ",")), params...)
+		return w.Albums, err
+	})
 }
 
 // GetAlbumTracks obtains Spotify catalog information about an album’s tracks.
@@ -99,8 +116,17 @@ func (s *Spotify) GetAlbums(ids []string, params ...Param) ([]*FullAlbum, error)
 //
 // Params: Market, Limit, Offset.
 func (s *Spotify) GetAlbumTracks(id string, params ...Param) (*SimplifiedTrackChunk, error) {
+	return s.GetAlbumTracksCtx(context.Background(), id, params...)
+}
+
+// GetAlbumTracksCtx is the same as GetAlbumTracks, but it sends the request with the given context.
+func (s *Spotify) GetAlbumTracksCtx(
+	ctx context.Context,
+	id string,
+	params ...Param,
+) (*SimplifiedTrackChunk, error) {
 	trackChunck := &SimplifiedTrackChunk{}
-	err := s.Get(trackChunck, fmt.Sprintf("/albums/%s/tracks", id), params...)
+	err := s.GetCtx(ctx, trackChunck, fmt.Sprintf("/albums/%s/tracks", id), params...)
 	return trackChunck, err
 }
 
@@ -110,8 +136,16 @@ func (s *Spotify) GetAlbumTracks(id string, params ...Param) (*SimplifiedTrackCh
 //
 // Scopes: ScopeUserLibraryRead.
 func (s *Spotify) GetUserSavedAlbums(params ...Param) (*SavedAlbumChunk, error) {
+	return s.GetUserSavedAlbumsCtx(context.Background(), params...)
+}
+
+// GetUserSavedAlbumsCtx is the same as GetUserSavedAlbums, but it sends the request with the given context.
+func (s *Spotify) GetUserSavedAlbumsCtx(
+	ctx context.Context,
+	params ...Param,
+) (*SavedAlbumChunk, error) {
 	albumChunk := &SavedAlbumChunk{}
-	err := s.Get(albumChunk, "/me/albums", params...)
+	err := s.GetCtx(ctx, albumChunk, "/me/albums", params...)
 	return albumChunk, err
 }
 
@@ -119,35 +153,65 @@ func (s *Spotify) GetUserSavedAlbums(params ...Param) (*SavedAlbumChunk, error)
 //
 // Scopes: ScopeUserLibraryModify.
 func (s *Spotify) SaveAlbumsForCurrentUser(ids []string) error {
-	return s.Put(nil, fmt.Sprintf("/me/albums?ids=%s", strings.Join(ids, ",")), []byte{})
+	return s.SaveAlbumsForCurrentUserCtx(context.Background(), ids)
+}
+
+// SaveAlbumsForCurrentUserCtx is the same as SaveAlbumsForCurrentUser, but it sends the request with the given context.
+func (s *Spotify) SaveAlbumsForCurrentUserCtx(ctx context.Context, ids []string) error {
+	return batchEach(ctx, s, ids, maxSavedAlbumIds, func(ctx context.Context, ids []string) error {
+		return s.PutCtx(ctx, nil, fmt.Sprintf("/me/albums?ids=%s", strings.Join(ids, ",")), []byte{})
+	})
 }
 
 // RemoveUserSavedAlbums removes one or more albums from the current user's 'Your Music' library.
 //
 // Scopes: ScopeUserLibraryModify.
 func (s *Spotify) RemoveUserSavedAlbums(ids []string) error {
-	return s.Delete(nil, fmt.Sprintf("/me/albums?ids=%s", strings.Join(ids, ",")), []byte{})
+	return s.RemoveUserSavedAlbumsCtx(context.Background(), ids)
+}
+
+// RemoveUserSavedAlbumsCtx is the same as RemoveUserSavedAlbums, but it sends the request with the given context.
+func (s *Spotify) RemoveUserSavedAlbumsCtx(ctx context.Context, ids []string) error {
+	return batchEach(ctx, s, ids, maxSavedAlbumIds, func(ctx context.Context, ids []string) error {
+		return s.DeleteCtx(ctx, nil, fmt.Sprintf("/me/albums?ids=%s", strings.Join(ids, ",")), []byte{})
+	})
 }
 
 // CheckUserSavedAlbums checks if one or more albums is already saved in the current Spotify user's 'Your Music' library.
 //
 // Scopes: ScopeUserLibraryRead.
 func (s *Spotify) CheckUserSavedAlbums(ids []string) ([]bool, error) {
-	containmentInfo := []bool{}
-	err := s.Get(
-		&containmentInfo,
-		fmt.Sprintf("/me/albums/contains?ids=%s", strings.Join(ids, ",")),
-	)
-	return containmentInfo, err
+	return s.CheckUserSavedAlbumsCtx(context.Background(), ids)
+}
+
+// CheckUserSavedAlbumsCtx is the same as CheckUserSavedAlbums, but it sends the request with the given context.
+func (s *Spotify) CheckUserSavedAlbumsCtx(ctx context.Context, ids []string) ([]bool, error) {
+	return batchIds(ctx, s, ids, maxSavedAlbumIds, func(ctx context.Context, ids []string) ([]bool, error) {
+		containmentInfo := []bool{}
+		err := s.GetCtx(
+			ctx,
+			&containmentInfo,
+			fmt.Sprintf("/me/albums/contains?ids=%s", strings.Join(ids, ",")),
+		)
+		return containmentInfo, err
+	})
 }
 
 // GetNewReleases obtains a list of new album releases featured in Spotify (shown, for example, on a Spotify player’s “Browse” tab).
 //
 // Params: Limit, Offset
 func (s *Spotify) GetNewReleases(params ...Param) (*SimplifiedAlbumChunk, error) {
+	return s.GetNewReleasesCtx(context.Background(), params...)
+}
+
+// GetNewReleasesCtx is the same as GetNewReleases, but it sends the request with the given context.
+func (s *Spotify) GetNewReleasesCtx(
+	ctx context.Context,
+	params ...Param,
+) (*SimplifiedAlbumChunk, error) {
 	var w struct {
 		Albums *SimplifiedAlbumChunk `json:"albums"`
 	}
-	err := s.Get(&w, "/browse/new-releases")
+	err := s.GetCtx(ctx, &w, "/browse/new-releases")
 	return w.Albums, err
 }
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"strings"
 )
@@ -38,26 +39,47 @@ type FullArtist struct {
 
 // GetArtist obtains Spotify catalog information for a single artist identified by their unique Spotify ID.
 func (s *Spotify) GetArtist(id string) (*FullArtist, error) {
+	return s.GetArtistCtx(context.Background(), id)
+}
+
+// GetArtistCtx is the same as GetArtist, but it sends the request with the given context.
+func (s *Spotify) GetArtistCtx(ctx context.Context, id string) (*FullArtist, error) {
 	artist := &FullArtist{}
-	err := s.Get(artist, fmt.Sprintf("/artists/%s", id))
+	err := s.GetCtx(ctx, artist, fmt.Sprintf("/artists/%s", id))
 	return artist, err
 }
 
 // GetArtists obtains Spotify catalog information for several artists based on their Spotify IDs.
 func (s *Spotify) GetArtists(ids []string) ([]*FullArtist, error) {
-	var w struct {
-		Artists []*FullArtist `json:"artists"`
-	}
-	err := s.Get(&w, fmt.Sprintf("/artists?ids=%s", strings.Join(ids, ",")))
-	return w.Artists, err
+	return s.GetArtistsCtx(context.Background(), ids)
+}
+
+// GetArtistsCtx is the same as GetArtists, but it sends the request with the given context.
+func (s *Spotify) GetArtistsCtx(ctx context.Context, ids []string) ([]*FullArtist, error) {
+	return batchIds(ctx, s, ids, maxArtistIds, func(ctx context.Context, ids []string) ([]*FullArtist, error) {
+		var w struct {
+			Artists []*FullArtist `json:"artists"`
+		}
+		err := s.GetCtx(ctx, &w, fmt.Sprintf("/artists?ids=%s", strings.Join(ids, ",")))
+		return w.Artists, err
+	})
 }
 
 // GetArtistAlbums obtains Spotify catalog information about an artist's albums.
 //
 // Params: IncludeGroups, Market, Limit, Offset.
 func (s *Spotify) GetArtistAlbums(id string, params ...Param) (*SimplifiedAlbumChunk, error) {
+	return s.GetArtistAlbumsCtx(context.Background(), id, params...)
+}
+
+// GetArtistAlbumsCtx is the same as GetArtistAlbums, but it sends the request with the given context.
+func (s *Spotify) GetArtistAlbumsCtx(
+	ctx context.Context,
+	id string,
+	params ...Param,
+) (*SimplifiedAlbumChunk, error) {
 	albumChunk := &SimplifiedAlbumChunk{}
-	err := s.Get(albumChunk, fmt.Sprintf("/artists/%s/albums", id), params...)
+	err := s.GetCtx(ctx, albumChunk, fmt.Sprintf("/artists/%s/albums", id), params...)
 	return albumChunk, err
 }
 
@@ -65,19 +87,36 @@ func (s *Spotify) GetArtistAlbums(id string, params ...Param) (*SimplifiedAlbumC
 //
 // Params: Market.
 func (s *Spotify) GetArtistTopTracks(id string, params ...Param) ([]*FullTrack, error) {
+	return s.GetArtistTopTracksCtx(context.Background(), id, params...)
+}
+
+// GetArtistTopTracksCtx is the same as GetArtistTopTracks, but it sends the request with the given context.
+func (s *Spotify) GetArtistTopTracksCtx(
+	ctx context.Context,
+	id string,
+	params ...Param,
+) ([]*FullTrack, error) {
 	var w struct {
 		Tracks []*FullTrack `json:"tracks"`
 	}
-	err := s.Get(&w, fmt.Sprintf("/artists/%s/top-tracks", id), params...)
+	err := s.GetCtx(ctx, &w, fmt.Sprintf("/artists/%s/top-tracks", id), params...)
 	return w.Tracks, err
 }
 
 // GetArtistRelatedArtists obtains Spotify catalog information about artists similar to a given artist.
 // Similarity is based on analysis of the Spotify community's listening history.
 func (s *Spotify) GetArtistRelatedArtists(id string) ([]*FullArtist, error) {
+	return s.GetArtistRelatedArtistsCtx(context.Background(), id)
+}
+
+// GetArtistRelatedArtistsCtx is the same as GetArtistRelatedArtists, but it sends the request with the given context.
+func (s *Spotify) GetArtistRelatedArtistsCtx(
+	ctx context.Context,
+	id string,
+) ([]*FullArtist, error) {
 	var w struct {
 		Artists []*FullArtist `json:"artists"`
 	}
-	err := s.Get(&w, fmt.Sprintf("/artists/%s/related-artists", id))
+	err := s.GetCtx(ctx, &w, fmt.Sprintf("/artists/%s/related-artists", id))
 	return w.Artists, err
 }
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"strings"
 )
@@ -36,26 +37,26 @@ type SimplifiedAudiobook struct {
 	// External URLs for this audiobook.
 	ExternalURLs ExternalURL `json:"external_urls"`
 	// A link to the Web API endpoint providing full details of the audiobook.
-	Href string
+	Href string `json:"href"`
 	// The Spotify ID for the audiobook.
-	Id string
+	Id string `json:"id"`
 	// The cover art for the audiobook in various sizes, widest first.
-	Images []Image
+	Images []Image `json:"images"`
 	// A list of the languages used in the audiobook, identified by their ISO 639 code.
-	Languages []string
+	Languages []string `json:"languages"`
 	// The media type of the audiobook.
-	MediaType string
+	MediaType string `json:"media_type"`
 	// The name of the audiobook.
-	Name      string
-	Narrators []Narrator
+	Name      string     `json:"name"`
+	Narrators []Narrator `json:"narrators"`
 	// The publisher of the audiobook.
-	Publisher string
+	Publisher string `json:"publisher"`
 	// The object type.
-	Type string
+	Type string `json:"type"`
 	// The Spotify URI for the audiobook.
-	URI string
+	URI string `json:"uri"`
 	// The number of chapters in this audiobook.
-	TotalChapters int
+	TotalChapters int `json:"total_chapters"`
 }
 
 // FullAudiobook contains all the data about the audiobook that can be returned by the Spotify API.
@@ -71,8 +72,17 @@ type FullAudiobook struct {
 //
 // Params: Market.
 func (s *Spotify) GetAudiobook(id string, params ...Param) (*FullAudiobook, error) {
+	return s.GetAudiobookCtx(context.Background(), id, params...)
+}
+
+// GetAudiobookCtx is the same as GetAudiobook, but it sends the request with the given context.
+func (s *Spotify) GetAudiobookCtx(
+	ctx context.Context,
+	id string,
+	params ...Param,
+) (*FullAudiobook, error) {
 	audiobook := &FullAudiobook{}
-	err := s.Get(audiobook, fmt.Sprintf("/audiobooks/%s", id), params...)
+	err := s.GetCtx(ctx, audiobook, fmt.Sprintf("/audiobooks/%s", id), params...)
 	return audiobook, err
 }
 
@@ -81,11 +91,22 @@ func (s *Spotify) GetAudiobook(id string, params ...Param) (*FullAudiobook, erro
 //
 // Params: Market.
 func (s *Spotify) GetAudiobooks(ids []string, params ...Param) ([]*FullAudiobook, error) {
-	var w struct {
-		Audiobooks []*FullAudiobook `json:"audiobooks"`
-	}
-	err := s.Get(&w, fmt.Sprintf("/audiobooks?ids=%s", strings.Join(ids, ",")), params...)
-	return w.Audiobooks, err
+	return s.GetAudiobooksCtx(context.Background(), ids, params...)
+}
+
+// GetAudiobooksCtx is the same as GetAudiobooks, but it sends the request with the given context.
+func (s *Spotify) GetAudiobooksCtx(
+	ctx context.Context,
+	ids []string,
+	params ...Param,
+) ([]*FullAudiobook, error) {
+	return batchIds(ctx, s, ids, maxAudiobookIds, func(ctx context.Context, ids []string) ([]*FullAudiobook, error) {
+		var w struct {
+			Audiobooks []*FullAudiobook `json:"audiobooks"`
+		}
+		err := s.GetCtx(ctx, &w, fmt.Sprintf("/audiobooks?ids=%s", strings.Join(ids, ",")), params...)
+		return w.Audiobooks, err
+	})
 }
 
 // GetAudiobookChapters obtains Spotify catalog information about an audiobook's chapters.
@@ -95,9 +116,18 @@ func (s *Spotify) GetAudiobooks(ids []string, params ...Param) ([]*FullAudiobook
 func (s *Spotify) GetAudiobookChapters(
 	id string,
 	params ...Param,
+) (*SimplifiedChapterChunk, error) {
+	return s.GetAudiobookChaptersCtx(context.Background(), id, params...)
+}
+
+// GetAudiobookChaptersCtx is the same as GetAudiobookChapters, but it sends the request with the given context.
+func (s *Spotify) GetAudiobookChaptersCtx(
+	ctx context.Context,
+	id string,
+	params ...Param,
 ) (*SimplifiedChapterChunk, error) {
 	chapterChunk := &SimplifiedChapterChunk{}
-	err := s.Get(chapterChunk, fmt.Sprintf("/audiobooks/%s/chapters", id), params...)
+	err := s.GetCtx(ctx, chapterChunk, fmt.Sprintf("/audiobooks/%s/chapters", id), params...)
 	return chapterChunk, err
 }
 
@@ -107,8 +137,16 @@ func (s *Spotify) GetAudiobookChapters(
 //
 // Scopes: ScopeUserLibraryRead.
 func (s *Spotify) GetUserSavedAudiobooks(params ...Param) (*SimplifiedAudiobookChunk, error) {
+	return s.GetUserSavedAudiobooksCtx(context.Background(), params...)
+}
+
+// GetUserSavedAudiobooksCtx is the same as GetUserSavedAudiobooks, but it sends the request with the given context.
+func (s *Spotify) GetUserSavedAudiobooksCtx(
+	ctx context.Context,
+	params ...Param,
+) (*SimplifiedAudiobookChunk, error) {
 	audiobookChunk := &SimplifiedAudiobookChunk{}
-	err := s.Get(audiobookChunk, "/me/audiobooks", params...)
+	err := s.GetCtx(ctx, audiobookChunk, "/me/audiobooks", params...)
 	return audiobookChunk, err
 }
 
@@ -116,24 +154,51 @@ func (s *Spotify) GetUserSavedAudiobooks(params ...Param) (*SimplifiedAudiobookC
 //
 // Scopes: ScopeUserLibraryModify.
 func (s *Spotify) SaveAudiobooksForCurrentUser(ids []string) error {
-	return s.Put(nil, fmt.Sprintf("/me/audiobooks?ids=%s", strings.Join(ids, ",")), []byte{})
+	return s.SaveAudiobooksForCurrentUserCtx(context.Background(), ids)
+}
+
+// SaveAudiobooksForCurrentUserCtx is the same as SaveAudiobooksForCurrentUser, but it sends the request with the given context.
+func (s *Spotify) SaveAudiobooksForCurrentUserCtx(ctx context.Context, ids []string) error {
+	return batchEach(ctx, s, ids, maxSavedAudiobookIds, func(ctx context.Context, ids []string) error {
+		return s.PutCtx(ctx, nil, fmt.Sprintf("/me/audiobooks?ids=%s", strings.Join(ids, ",")), []byte{})
+	})
 }
 
 // RemoveUserSavedAudiobooks removes one or more audiobooks from the Spotify user's library.
 //
 // Scopes: ScopeUserLibraryModify.
 func (s *Spotify) RemoveUserSavedAudiobooks(ids []string) error {
-	return s.Delete(nil, fmt.Sprintf("/me/audiobooks?ids=%s", strings.Join(ids, ",")), []byte{})
+	return s.RemoveUserSavedAudiobooksCtx(context.Background(), ids)
+}
+
+// RemoveUserSavedAudiobooksCtx is the same as RemoveUserSavedAudiobooks, but it sends the request with the given context.
+func (s *Spotify) RemoveUserSavedAudiobooksCtx(ctx context.Context, ids []string) error {
+	return batchEach(ctx, s, ids, maxSavedAudiobookIds, func(ctx context.Context, ids []string) error {
+		return s.DeleteCtx(
+			ctx,
+			nil,
+			fmt.Sprintf("/me/audiobooks?ids=%s", strings.Join(ids, ",")),
+			[]byte{},
+		)
+	})
 }
 
 // CheckUserSavedAudiobooks checks if one or more audiobooks are already saved in the current Spotify user's library.
 //
 // Scopes: ScopeUserLibraryRead.
 func (s *Spotify) CheckUserSavedAudiobooks(ids []string) ([]bool, error) {
-	containmentInfo := []bool{}
-	err := s.Get(
-		&containmentInfo,
-		fmt.Sprintf("/me/audiobooks/contains?ids=%s", strings.Join(ids, ",")),
-	)
-	return containmentInfo, err
+	return s.CheckUserSavedAudiobooksCtx(context.Background(), ids)
+}
+
+// CheckUserSavedAudiobooksCtx is the same as CheckUserSavedAudiobooks, but it sends the request with the given context.
+func (s *Spotify) CheckUserSavedAudiobooksCtx(ctx context.Context, ids []string) ([]bool, error) {
+	return batchIds(ctx, s, ids, maxSavedAudiobookIds, func(ctx context.Context, ids []string) ([]bool, error) {
+		containmentInfo := []bool{}
+		err := s.GetCtx(
+			ctx,
+			&containmentInfo,
+			fmt.Sprintf("/me/audiobooks/contains?ids=%s", strings.Join(ids, ",")),
+		)
+		return containmentInfo, err
+	})
 }
@@ -60,6 +60,9 @@ func TestGetAudiobookChapters(t *testing.T) {
 	}
 	sourceChapterChunk := &SimplifiedChapterChunk{}
 	testDiffs(t, body, sourceChapterChunk, chapterChunk)
+	if len(chapterChunk.Items) != 1 || chapterChunk.Items[0].Id != "5Xt5DXGzch68nYYamXrNxZ" {
+		t.Errorf("Unexpected chapters: %+v", chapterChunk.Items)
+	}
 }
 
 func TestGetUserSavedAudiobooks(t *testing.T) {
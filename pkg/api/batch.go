@@ -0,0 +1,166 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// The number of the batches sent at the same time, used unless WithBatchConcurrency is supplied.
+const defaultBatchConcurrency = 4
+
+// The maximum numbers of the ids Spotify accepts in the single request to the multi-id endpoints.
+const (
+	maxAlbumIds          = 20
+	maxArtistIds         = 50
+	maxAudiobookIds      = 50
+	maxChapterIds        = 50
+	maxEpisodeIds        = 50
+	maxShowIds           = 50
+	maxTrackIds          = 50
+	maxAudioFeatureIds   = 100
+	maxSavedAlbumIds     = 20
+	maxSavedAudiobookIds = 50
+	maxSavedEpisodeIds   = 50
+	maxSavedShowIds      = 50
+	maxSavedTrackIds     = 50
+	maxFollowIds         = 50
+	maxPlaylistFollowIds = 5
+)
+
+// BatchFailure describes the batch of ids, which failed when the method split its ids into several requests.
+type BatchFailure struct {
+	// The index of the first id of the batch among the ids passed to the method.
+	Offset int
+	// The ids of the batch.
+	Ids []string
+	// The error the request of the batch failed with.
+	Err error
+}
+
+// Error returns the range of the ids of the batch along with the error it failed with.
+func (f *BatchFailure) Error() string {
+	return fmt.Sprintf("batch of ids %d-%d: %v", f.Offset, f.Offset+len(f.Ids)-1, f.Err)
+}
+
+// Unwrap returns the error the request of the batch failed with.
+func (f *BatchFailure) Unwrap() error {
+	return f.Err
+}
+
+// BatchError is returned by the methods accepting more ids than Spotify allows in the single request,
+// such as GetTracks or SaveTracksForCurrentUser, when some of the batches they were split into failed.
+// The results of the other batches are still returned, while the ones of the failed batches are left empty.
+//
+// errors.Is and errors.As look into the errors of every failed batch, so the error can be checked
+// the same way as the one of the single request, for example errors.Is(err, ErrRateLimited).
+type BatchError struct {
+	// The failed batches, in the order of their ids.
+	Failures []*BatchFailure
+	// The number of the batches the ids were split into.
+	Batches int
+}
+
+// Error returns the number of the failed batches along with the first failure.
+func (e *BatchError) Error() string {
+	return fmt.Sprintf(
+		"spotify batch error: %d of %d batches failed, first %v",
+		len(e.Failures),
+		e.Batches,
+		e.Failures[0],
+	)
+}
+
+// Unwrap returns the failures of the batches.
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, len(e.Failures))
+	for i, failure := range e.Failures {
+		errs[i] = failure
+	}
+	return errs
+}
+
+// batchIds splits the ids into the batches of up to size ids and calls the fetch for every batch,
+// with up to the batch concurrency of the client calls at the same time.
+// The results of the batches are merged in the order of the ids, keeping the nil items Spotify returns for the unknown ids.
+// If the ids fit into the single batch, the fetch is called once, and its result and error are returned as is.
+// Otherwise the failed batches are reported with the *BatchError, and their items are left zero.
+func batchIds[T any](
+	ctx context.Context,
+	s *Spotify,
+	ids []string,
+	size int,
+	fetch func(ctx context.Context, ids []string) ([]T, error),
+) ([]T, error) {
+	if len(ids) <= size {
+		return fetch(ctx, ids)
+	}
+
+	workers := s.batchConcurrency
+	if workers < 1 {
+		workers = 1
+	}
+
+	batches := (len(ids) + size - 1) / size
+	result := make([]T, len(ids))
+	errs := make([]error, batches)
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	for i := 0; i < batches; i++ {
+		start, end := i*size, (i+1)*size
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+			continue
+		}
+		wg.Add(1)
+		go func(i, start, end int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			items, err := fetch(ctx, ids[start:end])
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			copy(result[start:end], items)
+		}(i, start, end)
+	}
+	wg.Wait()
+
+	batchErr := &BatchError{Batches: batches}
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+		start, end := i*size, (i+1)*size
+		if end > len(ids) {
+			end = len(ids)
+		}
+		batchErr.Failures = append(batchErr.Failures, &BatchFailure{start, ids[start:end], err})
+	}
+	if len(batchErr.Failures) > 0 {
+		return result, batchErr
+	}
+	return result, nil
+}
+
+// batchEach is the same as batchIds, but for the requests without the result, such as saving the tracks.
+func batchEach(
+	ctx context.Context,
+	s *Spotify,
+	ids []string,
+	size int,
+	send func(ctx context.Context, ids []string) error,
+) error {
+	_, err := batchIds(ctx, s, ids, size, func(ctx context.Context, ids []string) ([]struct{}, error) {
+		return nil, send(ctx, ids)
+	})
+	return err
+}
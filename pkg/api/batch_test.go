@@ -0,0 +1,156 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// testBatchHandler serves the tracks with the requested ids, returning null for the ones starting with "unknown",
+// and failing the requests containing the ones starting with "fail".
+func testBatchHandler(mu *sync.Mutex, batches *[][]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ids := strings.Split(r.URL.Query().Get("ids"), ",")
+		mu.Lock()
+		*batches = append(*batches, ids)
+		mu.Unlock()
+
+		var body struct {
+			Tracks []*FullTrack `json:"tracks"`
+		}
+		for _, id := range ids {
+			if strings.HasPrefix(id, "fail") {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			if strings.HasPrefix(id, "unknown") {
+				body.Tracks = append(body.Tracks, nil)
+				continue
+			}
+			body.Tracks = append(body.Tracks, &FullTrack{SimplifiedTrack: SimplifiedTrack{
+				AudioRecording: AudioRecording{Id: id},
+			}})
+		}
+		data, err := json.Marshal(body)
+		if err != nil {
+			panic(err)
+		}
+		if err := writeResponse(w, data); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func testBatchIds(n int) []string {
+	ids := make([]string, n)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("track-%d", i)
+	}
+	return ids
+}
+
+func TestBatchGetTracks(t *testing.T) {
+	var mu sync.Mutex
+	var batches [][]string
+	server, spotify := testServer(testBatchHandler(&mu, &batches), WithBatchConcurrency(2))
+	defer server.Close()
+
+	ids := testBatchIds(120)
+	ids[7] = "unknown-7"
+	ids[64] = "unknown-64"
+	tracks, err := spotify.GetTracks(ids)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(batches) != 3 {
+		t.Errorf("Expected 3 batches, got %d", len(batches))
+	}
+	for _, batch := range batches {
+		if len(batch) > maxTrackIds {
+			t.Errorf("Expected at most %d ids in the batch, got %d", maxTrackIds, len(batch))
+		}
+	}
+	if len(tracks) != len(ids) {
+		t.Fatalf("Expected %d tracks, got %d", len(ids), len(tracks))
+	}
+	for i, track := range tracks {
+		if strings.HasPrefix(ids[i], "unknown") {
+			if track != nil {
+				t.Errorf("Expected nil track for %s, got %s", ids[i], track.Id)
+			}
+			continue
+		}
+		if track == nil || track.Id != ids[i] {
+			t.Errorf("Expected track %s at %d, got %+v", ids[i], i, track)
+		}
+	}
+}
+
+func TestBatchPartialFailure(t *testing.T) {
+	var mu sync.Mutex
+	var batches [][]string
+	server, spotify := testServer(testBatchHandler(&mu, &batches))
+	defer server.Close()
+
+	ids := testBatchIds(150)
+	ids[60] = "fail-60"
+	tracks, err := spotify.GetTracks(ids)
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("Expected *BatchError, got %v", err)
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected %v, got %v", ErrNotFound, err)
+	}
+	if batchErr.Batches != 3 || len(batchErr.Failures) != 1 {
+		t.Fatalf("Expected 1 of 3 batches to fail, got %d of %d", len(batchErr.Failures), batchErr.Batches)
+	}
+	failure := batchErr.Failures[0]
+	if failure.Offset != 50 || len(failure.Ids) != 50 || failure.Ids[10] != "fail-60" {
+		t.Errorf("Unexpected failure: %d %v", failure.Offset, failure.Ids)
+	}
+
+	if len(tracks) != len(ids) {
+		t.Fatalf("Expected %d tracks, got %d", len(ids), len(tracks))
+	}
+	if tracks[49] == nil || tracks[49].Id != ids[49] || tracks[100] == nil || tracks[100].Id != ids[100] {
+		t.Errorf("Expected tracks of the other batches to be returned")
+	}
+	if tracks[50] != nil || tracks[99] != nil {
+		t.Errorf("Expected tracks of the failed batch to be nil")
+	}
+}
+
+func TestBatchSaveTracks(t *testing.T) {
+	var mu sync.Mutex
+	var methods []string
+	var sizes []int
+	server, spotify := testServer(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		methods = append(methods, r.Method)
+		sizes = append(sizes, len(strings.Split(r.URL.Query().Get("ids"), ",")))
+	})
+	defer server.Close()
+
+	err := spotify.SaveTracksForCurrentUser(testBatchIds(101))
+	if err != nil {
+		t.Fatal(err)
+	}
+	total := 0
+	for i, size := range sizes {
+		if methods[i] != http.MethodPut || size > maxSavedTrackIds {
+			t.Errorf("Unexpected batch: %s of %d ids", methods[i], size)
+		}
+		total += size
+	}
+	if len(sizes) != 3 || total != 101 {
+		t.Errorf("Expected 101 ids in 3 batches, got %v", sizes)
+	}
+}
@@ -0,0 +1,301 @@
+package api
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheEntry is the cached response of the GET request.
+type CacheEntry struct {
+	// The body of the response.
+	Body []byte `json:"body"`
+	// The ETag of the response, used to revalidate the entry once it is stale.
+	ETag string `json:"etag"`
+	// The time until which the entry can be used without revalidation, taken from the max-age directive.
+	Expires time.Time `json:"expires"`
+}
+
+// fresh checks if the entry can be used without revalidation.
+func (e *CacheEntry) fresh(now time.Time) bool {
+	return now.Before(e.Expires)
+}
+
+// Cache stores the responses of the GET requests made by the Spotify client.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the entry stored under the key, if any.
+	Get(key string) (*CacheEntry, bool)
+	// Set stores the entry under the key, replacing the previous one.
+	Set(key string, entry *CacheEntry)
+}
+
+// MemoryCache is the Cache, which keeps a limited number of entries in memory,
+// evicting the least recently used ones first.
+// It is recommended to create MemoryCache through the NewMemoryCache function.
+type MemoryCache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List
+}
+
+// memoryCacheItem is the value of the MemoryCache order list.
+type memoryCacheItem struct {
+	key   string
+	entry *CacheEntry
+}
+
+// NewMemoryCache creates the MemoryCache, which holds up to capacity entries.
+func NewMemoryCache(capacity int) *MemoryCache {
+	return &MemoryCache{
+		capacity: capacity,
+		entries:  map[string]*list.Element{},
+		order:    list.New(),
+	}
+}
+
+// Get returns the entry stored under the key, marking it as recently used.
+func (c *MemoryCache) Get(key string) (*CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*memoryCacheItem).entry, true
+}
+
+// Set stores the entry under the key, evicting the least recently used entry if the cache is full.
+func (c *MemoryCache) Set(key string, entry *CacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*memoryCacheItem).entry = entry
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&memoryCacheItem{key, entry})
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*memoryCacheItem).key)
+	}
+}
+
+// Len returns the number of entries in the cache.
+func (c *MemoryCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// DiskCache is the Cache, which keeps every entry in a separate file of the directory,
+// so the entries survive restarts of the application.
+// It is recommended to create DiskCache through the NewDiskCache function.
+type DiskCache struct {
+	dir string
+}
+
+// NewDiskCache creates the DiskCache, storing entries in the given directory.
+// The directory is created if it does not exist.
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &DiskCache{dir}, nil
+}
+
+// Get reads the entry stored under the key. Unreadable entries are treated as missing.
+func (c *DiskCache) Get(key string) (*CacheEntry, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	entry := &CacheEntry{}
+	if err := json.Unmarshal(data, entry); err != nil {
+		return nil, false
+	}
+	return entry, true
+}
+
+// Set writes the entry under the key. The file is replaced atomically,
+// so concurrent readers never see a partially written entry. Failed writes are ignored.
+func (c *DiskCache) Set(key string, entry *CacheEntry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	tmp, err := os.CreateTemp(c.dir, "entry-*")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), c.path(key))
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+}
+
+// path returns the name of the file storing the entry with the given key.
+func (c *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// cacheKey returns the key the response to the request is cached under,
+// or false if the request should not be cached.
+// If the client has the token source, every response is keyed by the token as well,
+// as not only the /me endpoints depend on the user, but also the private playlists and the market=from_token requests.
+// Responses of the /me endpoints are never shared between users, so if the token is unknown, they are not cached at all.
+func (s *Spotify) cacheKey(req *Request) (string, bool) {
+	if req.Method != http.MethodGet {
+		return "", false
+	}
+
+	key := req.Endpoint + "?" + req.Params.Encode()
+	userScoped := req.Endpoint == "/me" || strings.HasPrefix(req.Endpoint, "/me/")
+	if s.tokenSource == nil && !userScoped {
+		return key, true
+	}
+
+	token, ok := s.tokenKey()
+	if !ok {
+		return "", false
+	}
+	return key + "#" + token, true
+}
+
+// tokenKey returns the hash of the current access token, or false if the token is unknown.
+func (s *Spotify) tokenKey() (string, bool) {
+	if s.tokenSource == nil {
+		return "", false
+	}
+	token, err := s.tokenSource.Token()
+	if err != nil {
+		return "", false
+	}
+
+	sum := sha256.Sum256([]byte(token.AccessToken))
+	return hex.EncodeToString(sum[:16]), true
+}
+
+// cacheMiddleware serves GET requests from the cache of the client, as long as the entries are fresh.
+// Stale entries with the ETag are revalidated with the If-None-Match header,
+// and served again if Spotify answers with 304 Not Modified.
+func (s *Spotify) cacheMiddleware(next Handler) Handler {
+	return func(ctx context.Context, req *Request) (*Response, error) {
+		key, ok := s.cacheKey(req)
+		if !ok {
+			return next(ctx, req)
+		}
+
+		entry, cached := s.cache.Get(key)
+		if cached && entry.fresh(time.Now()) {
+			return NewResponse(http.StatusOK, entry.Body), nil
+		}
+		if cached && entry.ETag != "" {
+			req.Header.Set("If-None-Match", entry.ETag)
+		}
+
+		res, err := next(ctx, req)
+		if err != nil {
+			return res, err
+		}
+
+		switch {
+		case cached && res.StatusCode == http.StatusNotModified:
+			if _, err := res.readBody(); err != nil {
+				return nil, err
+			}
+			revalidated := &CacheEntry{
+				Body:    entry.Body,
+				ETag:    entry.ETag,
+				Expires: cacheExpires(res.Header),
+			}
+			if etag := res.Header.Get("ETag"); etag != "" {
+				revalidated.ETag = etag
+			}
+			s.cache.Set(key, revalidated)
+			return NewResponse(http.StatusOK, revalidated.Body), nil
+		case res.StatusCode == http.StatusOK && cacheable(res.Header):
+			body, err := res.readBody()
+			if err != nil {
+				return nil, err
+			}
+			s.cache.Set(key, &CacheEntry{
+				Body:    body,
+				ETag:    res.Header.Get("ETag"),
+				Expires: cacheExpires(res.Header),
+			})
+			res.Body = io.NopCloser(bytes.NewReader(body))
+			return res, nil
+		}
+		return res, nil
+	}
+}
+
+// cacheable checks if the response with the given headers can be stored.
+// Responses are stored if they can be revalidated, or if they stay fresh for some time.
+func cacheable(header http.Header) bool {
+	for _, directive := range cacheDirectives(header) {
+		if directive == "no-store" {
+			return false
+		}
+	}
+	return header.Get("ETag") != "" || cacheExpires(header).After(time.Now())
+}
+
+// cacheExpires calculates the time until which the response stays fresh, using the max-age directive.
+// Responses without max-age, or with no-cache, expire immediately.
+func cacheExpires(header http.Header) time.Time {
+	now := time.Now()
+	for _, directive := range cacheDirectives(header) {
+		if directive == "no-cache" {
+			return now
+		}
+	}
+	for _, directive := range cacheDirectives(header) {
+		value, ok := strings.CutPrefix(directive, "max-age=")
+		if !ok {
+			continue
+		}
+		if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+			return now.Add(time.Duration(seconds) * time.Second)
+		}
+	}
+	return now
+}
+
+// cacheDirectives splits the Cache-Control header into the lowercase directives.
+func cacheDirectives(header http.Header) []string {
+	var directives []string
+	for _, value := range header.Values("Cache-Control") {
+		for _, directive := range strings.Split(value, ",") {
+			directives = append(directives, strings.ToLower(strings.TrimSpace(directive)))
+		}
+	}
+	return directives
+}
@@ -0,0 +1,194 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func testCacheHandler(calls *int, header http.Header, body []byte) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		for k, v := range header {
+			w.Header()[k] = v
+		}
+		if etag := header.Get("ETag"); etag != "" && r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		err := writeResponse(w, body)
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+func TestMemoryCacheEviction(t *testing.T) {
+	cache := NewMemoryCache(2)
+	cache.Set("a", &CacheEntry{Body: []byte("a")})
+	cache.Set("b", &CacheEntry{Body: []byte("b")})
+	cache.Get("a")
+	cache.Set("c", &CacheEntry{Body: []byte("c")})
+
+	if _, ok := cache.Get("b"); ok {
+		t.Errorf("Expected least recently used entry to be evicted")
+	}
+	if _, ok := cache.Get("a"); !ok {
+		t.Errorf("Expected recently used entry to be kept")
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Expected 2 entries, got %d", cache.Len())
+	}
+}
+
+func TestDiskCache(t *testing.T) {
+	cache, err := NewDiskCache(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expires := time.Now().Add(time.Minute).Round(0)
+	cache.Set("key", &CacheEntry{Body: []byte(`{"id":"test"}`), ETag: `"v1"`, Expires: expires})
+
+	entry, ok := cache.Get("key")
+	if !ok {
+		t.Fatal("Expected entry to be stored")
+	}
+	if string(entry.Body) != `{"id":"test"}` || entry.ETag != `"v1"` || !entry.Expires.Equal(expires) {
+		t.Errorf("Unexpected entry: %+v", entry)
+	}
+	if _, ok := cache.Get("missing"); ok {
+		t.Errorf("Expected missing entry not to be found")
+	}
+}
+
+func TestCacheMaxAge(t *testing.T) {
+	calls := 0
+	header := http.Header{"Cache-Control": []string{"public, max-age=60"}}
+	server, spotify := testServer(
+		testCacheHandler(&calls, header, []byte(`{"id":"`+testId+`"}`)),
+		WithCache(NewMemoryCache(10)),
+	)
+	defer server.Close()
+
+	for i := 0; i < 3; i++ {
+		track, err := spotify.GetTrack(testId)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if track.Id != testId {
+			t.Errorf("Expected %s, got %s", testId, track.Id)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 call, got %d", calls)
+	}
+
+	_, err := spotify.GetTrack(testId, Market("ES"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected request with different params to be sent, got %d calls", calls)
+	}
+}
+
+func TestCacheRevalidation(t *testing.T) {
+	calls := 0
+	header := http.Header{"Cache-Control": []string{"max-age=0"}, "Etag": []string{`"v1"`}}
+	server, spotify := testServer(
+		testCacheHandler(&calls, header, []byte(`{"id":"`+testId+`"}`)),
+		WithCache(NewMemoryCache(10)),
+	)
+	defer server.Close()
+
+	for i := 0; i < 2; i++ {
+		track, err := spotify.GetTrack(testId)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if track.Id != testId {
+			t.Errorf("Expected %s from revalidated entry, got %s", testId, track.Id)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 calls, got %d", calls)
+	}
+}
+
+func TestCacheNoStore(t *testing.T) {
+	calls := 0
+	header := http.Header{"Cache-Control": []string{"no-store, max-age=60"}}
+	server, spotify := testServer(
+		testCacheHandler(&calls, header, []byte(`{"id":"`+testId+`"}`)),
+		WithCache(NewMemoryCache(10)),
+	)
+	defer server.Close()
+
+	for i := 0; i < 2; i++ {
+		if _, err := spotify.GetTrack(testId); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 calls, got %d", calls)
+	}
+}
+
+func TestCacheUserScoped(t *testing.T) {
+	calls := 0
+	header := http.Header{"Cache-Control": []string{"max-age=60"}}
+	cache := NewMemoryCache(10)
+	server, alice := testServer(
+		testCacheHandler(&calls, header, []byte(`{"id":"alice"}`)),
+		WithCache(cache),
+		WithToken(&oauth2.Token{AccessToken: "alice"}),
+	)
+	defer server.Close()
+	newClient := func(opts ...Option) *Spotify {
+		opts = append([]Option{WithBaseURL(server.URL), WithCache(cache)}, opts...)
+		return NewSpotifyClient(context.Background(), opts...)
+	}
+	bob := newClient(WithToken(&oauth2.Token{AccessToken: "bob"}))
+	anonymous := newClient()
+
+	for _, spotify := range []*Spotify{alice, alice, bob, anonymous, anonymous} {
+		if _, err := spotify.GetCurrentUserProfile(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls != 4 {
+		t.Errorf("Expected 4 calls, got %d", calls)
+	}
+}
+
+func TestCacheTokenScoped(t *testing.T) {
+	calls := 0
+	header := http.Header{"Cache-Control": []string{"max-age=60"}}
+	cache := NewMemoryCache(10)
+	server, alice := testServer(
+		testCacheHandler(&calls, header, []byte(`{"id":"`+testId+`"}`)),
+		WithCache(cache),
+		WithToken(&oauth2.Token{AccessToken: "alice"}),
+	)
+	defer server.Close()
+	bob := NewSpotifyClient(
+		context.Background(),
+		WithBaseURL(server.URL),
+		WithCache(cache),
+		WithToken(&oauth2.Token{AccessToken: "bob"}),
+	)
+
+	for _, spotify := range []*Spotify{alice, alice, bob, bob} {
+		if _, err := spotify.GetPlaylist(testId, Market("from_token")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 calls, got %d", calls)
+	}
+}
@@ -1,6 +1,9 @@
 package api
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // Category contains the category data that can be returned by the Spotify API
 // It is used to tag items in Spotify.
@@ -19,8 +22,17 @@ type Category struct {
 //
 // Params: Locale.
 func (s *Spotify) GetBrowseCategory(id string, params ...Param) (*Category, error) {
+	return s.GetBrowseCategoryCtx(context.Background(), id, params...)
+}
+
+// GetBrowseCategoryCtx is the same as GetBrowseCategory, but it sends the request with the given context.
+func (s *Spotify) GetBrowseCategoryCtx(
+	ctx context.Context,
+	id string,
+	params ...Param,
+) (*Category, error) {
 	category := &Category{}
-	err := s.Get(category, fmt.Sprintf("/browse/categories/%s", id), params...)
+	err := s.GetCtx(ctx, category, fmt.Sprintf("/browse/categories/%s", id), params...)
 	return category, err
 }
 
@@ -28,9 +40,17 @@ func (s *Spotify) GetBrowseCategory(id string, params ...Param) (*Category, erro
 //
 // Params: Locale, Limit, Offset.
 func (s *Spotify) GetBrowseCategories(params ...Param) (*CategoryChunk, error) {
+	return s.GetBrowseCategoriesCtx(context.Background(), params...)
+}
+
+// GetBrowseCategoriesCtx is the same as GetBrowseCategories, but it sends the request with the given context.
+func (s *Spotify) GetBrowseCategoriesCtx(
+	ctx context.Context,
+	params ...Param,
+) (*CategoryChunk, error) {
 	var w struct {
 		Categories *CategoryChunk `json:"categories"`
 	}
-	err := s.Get(&w, "/browse/categories", params...)
+	err := s.GetCtx(ctx, &w, "/browse/categories", params...)
 	return w.Categories, err
 }
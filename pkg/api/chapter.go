@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"strings"
 )
@@ -50,8 +51,17 @@ type FullChapter struct {
 //
 // Params: Market.
 func (s *Spotify) GetChapter(id string, params ...Param) (*FullChapter, error) {
+	return s.GetChapterCtx(context.Background(), id, params...)
+}
+
+// GetChapterCtx is the same as GetChapter, but it sends the request with the given context.
+func (s *Spotify) GetChapterCtx(
+	ctx context.Context,
+	id string,
+	params ...Param,
+) (*FullChapter, error) {
 	chapter := &FullChapter{}
-	err := s.Get(chapter, fmt.Sprintf("/chapters/%s", id), params...)
+	err := s.GetCtx(ctx, chapter, fmt.Sprintf("/chapters/%s", id), params...)
 	return chapter, err
 }
 
@@ -60,9 +70,20 @@ func (s *Spotify) GetChapter(id string, params ...Param) (*FullChapter, error) {
 //
 // Params: Market.
 func (s *Spotify) GetChapters(ids []string, params ...Param) ([]*FullChapter, error) {
-	var w struct {
-		Chapters []*FullChapter `json:"chapters"`
-	}
-	err := s.Get(&w, fmt.Sprintf("/chapters?ids=%s", strings.Join(ids, ",")), params...)
-	return w.Chapters, err
+	return s.GetChaptersCtx(context.Background(), ids, params...)
+}
+
+// GetChaptersCtx is the same as GetChapters, but it sends the request with the given context.
+func (s *Spotify) GetChaptersCtx(
+	ctx context.Context,
+	ids []string,
+	params ...Param,
+) ([]*FullChapter, error) {
+	return batchIds(ctx, s, ids, maxChapterIds, func(ctx context.Context, ids []string) ([]*FullChapter, error) {
+		var w struct {
+			Chapters []*FullChapter `json:"chapters"`
+		}
+		err := s.GetCtx(ctx, &w, fmt.Sprintf("/chapters?ids=%s", strings.Join(ids, ",")), params...)
+		return w.Chapters, err
+	})
 }
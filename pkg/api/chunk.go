@@ -1,5 +1,10 @@
 package api
 
+import (
+	"context"
+	"net/url"
+)
+
 // Chunk represents paged set of data
 type Chunk struct {
 	// A link to the Web API endpoint returning the full result of the request
@@ -16,40 +21,227 @@ type Chunk struct {
 	Total int `json:"total"`
 }
 
+// page returns the Chunk itself. It is promoted to every XxxChunk type, letting the Paginator follow its links.
+func (c *Chunk) page() *Chunk {
+	return c
+}
+
+// HasNext checks if there is the page after this one.
+func (c *Chunk) HasNext() bool {
+	return c.Next != ""
+}
+
+// HasPrevious checks if there is the page before this one.
+func (c *Chunk) HasPrevious() bool {
+	return c.Previous != ""
+}
+
+// CursorChunk represents paged set of data, which is addressed by the cursors instead of the offset.
+type CursorChunk struct {
+	// A link to the Web API endpoint returning the full result of the request
+	Href string `json:"href"`
+	// The maximum number of items in the response (as set in the query or by default).
+	Limit int `json:"limit"`
+	// URL to the next page of items, which carries the cursor of this page. ( null if none)
+	Next string `json:"next"`
+	// The cursors used to find the next set of items.
+	Cursors Cursors `json:"cursors"`
+	// The total number of items available to return.
+	Total int `json:"total"`
+}
+
+// page returns the Chunk describing the CursorChunk.
+// Cursor pages have no offset, so they are walked only by following their Next links.
+func (c *CursorChunk) page() *Chunk {
+	return &Chunk{Href: c.Href, Limit: c.Limit, Next: c.Next}
+}
+
+// HasNext checks if there is the page after this one.
+func (c *CursorChunk) HasNext() bool {
+	return c.Next != ""
+}
+
+// AfterLink returns the link to the page of the items after this one, addressed by the Cursors.After,
+// or the empty string if there is no such cursor.
+func (c *CursorChunk) AfterLink() string {
+	return c.cursorLink("after", c.Cursors.After)
+}
+
+// BeforeLink returns the link to the page of the items before this one, addressed by the Cursors.Before,
+// or the empty string if there is no such cursor.
+func (c *CursorChunk) BeforeLink() string {
+	return c.cursorLink("before", c.Cursors.Before)
+}
+
+// cursorChunk returns the CursorChunk itself. It is promoted to every XxxCursorChunk type,
+// letting the Paginator follow its cursors.
+func (c *CursorChunk) cursorChunk() *CursorChunk {
+	return c
+}
+
+// cursorLink returns the Href of the page, with the after and before params replaced by the given cursor.
+func (c *CursorChunk) cursorLink(name, cursor string) string {
+	if cursor == "" || c.Href == "" {
+		return ""
+	}
+	link, err := url.Parse(c.Href)
+	if err != nil {
+		return ""
+	}
+	query := link.Query()
+	query.Del("after")
+	query.Del("before")
+	query.Set(name, cursor)
+	link.RawQuery = query.Encode()
+	return link.String()
+}
+
 // SimplifiedAlbumChunk represents a paged set of SimplifiedAlbum items
 type SimplifiedAlbumChunk struct {
 	Chunk
 	Items []SimplifiedAlbum `json:"items"`
 }
 
+// items returns the SimplifiedAlbum items of the page.
+func (c *SimplifiedAlbumChunk) items() []SimplifiedAlbum {
+	return c.Items
+}
+
+// NextPage fetches the page after this one with the client, or returns ErrNoPage if there is none.
+func (c *SimplifiedAlbumChunk) NextPage(ctx context.Context, s *Spotify) (*SimplifiedAlbumChunk, error) {
+	return fetchPage[SimplifiedAlbumChunk](ctx, s, c.Next)
+}
+
+// PreviousPage fetches the page before this one with the client, or returns ErrNoPage if there is none.
+func (c *SimplifiedAlbumChunk) PreviousPage(ctx context.Context, s *Spotify) (*SimplifiedAlbumChunk, error) {
+	return fetchPage[SimplifiedAlbumChunk](ctx, s, c.Previous)
+}
+
 // SavedAlbumChunk represents a paged set of SavedAlbum items
 type SavedAlbumChunk struct {
 	Chunk
 	Items []SavedAlbum `json:"items"`
 }
 
+// items returns the SavedAlbum items of the page.
+func (c *SavedAlbumChunk) items() []SavedAlbum {
+	return c.Items
+}
+
+// NextPage fetches the page after this one with the client, or returns ErrNoPage if there is none.
+func (c *SavedAlbumChunk) NextPage(ctx context.Context, s *Spotify) (*SavedAlbumChunk, error) {
+	return fetchPage[SavedAlbumChunk](ctx, s, c.Next)
+}
+
+// PreviousPage fetches the page before this one with the client, or returns ErrNoPage if there is none.
+func (c *SavedAlbumChunk) PreviousPage(ctx context.Context, s *Spotify) (*SavedAlbumChunk, error) {
+	return fetchPage[SavedAlbumChunk](ctx, s, c.Previous)
+}
+
+// FullArtistCursorChunk represents a paged set of FullArtist items, addressed by the cursors
+type FullArtistCursorChunk struct {
+	CursorChunk
+	Items []FullArtist `json:"items"`
+}
+
+// items returns the FullArtist items of the page.
+func (c *FullArtistCursorChunk) items() []FullArtist {
+	return c.Items
+}
+
+// NextPage fetches the page after this one with the client, or returns ErrNoPage if there is none.
+func (c *FullArtistCursorChunk) NextPage(ctx context.Context, s *Spotify) (*FullArtistCursorChunk, error) {
+	return fetchPage[FullArtistCursorChunk](ctx, s, c.Next)
+}
+
+// AfterPage fetches the page of the items after the Cursors.After of this one,
+// or returns ErrNoPage if there is no such cursor.
+func (c *FullArtistCursorChunk) AfterPage(ctx context.Context, s *Spotify) (*FullArtistCursorChunk, error) {
+	return fetchPage[FullArtistCursorChunk](ctx, s, c.AfterLink())
+}
+
 // FullArtistChunk represents a paged set of FullArtist items
 type FullArtistChunk struct {
 	Chunk
 	Items []FullArtist `json:"items"`
 }
 
+// items returns the FullArtist items of the page.
+func (c *FullArtistChunk) items() []FullArtist {
+	return c.Items
+}
+
+// NextPage fetches the page after this one with the client, or returns ErrNoPage if there is none.
+func (c *FullArtistChunk) NextPage(ctx context.Context, s *Spotify) (*FullArtistChunk, error) {
+	return fetchPage[FullArtistChunk](ctx, s, c.Next)
+}
+
+// PreviousPage fetches the page before this one with the client, or returns ErrNoPage if there is none.
+func (c *FullArtistChunk) PreviousPage(ctx context.Context, s *Spotify) (*FullArtistChunk, error) {
+	return fetchPage[FullArtistChunk](ctx, s, c.Previous)
+}
+
 // SimplifiedAudiobookChunk represents a paged set of SimplifiedAudiobook items
 type SimplifiedAudiobookChunk struct {
 	Chunk
 	Items []SimplifiedAudiobook `json:"items"`
 }
 
+// items returns the SimplifiedAudiobook items of the page.
+func (c *SimplifiedAudiobookChunk) items() []SimplifiedAudiobook {
+	return c.Items
+}
+
+// NextPage fetches the page after this one with the client, or returns ErrNoPage if there is none.
+func (c *SimplifiedAudiobookChunk) NextPage(ctx context.Context, s *Spotify) (*SimplifiedAudiobookChunk, error) {
+	return fetchPage[SimplifiedAudiobookChunk](ctx, s, c.Next)
+}
+
+// PreviousPage fetches the page before this one with the client, or returns ErrNoPage if there is none.
+func (c *SimplifiedAudiobookChunk) PreviousPage(ctx context.Context, s *Spotify) (*SimplifiedAudiobookChunk, error) {
+	return fetchPage[SimplifiedAudiobookChunk](ctx, s, c.Previous)
+}
+
 // CategoryChunk represents a paged set of Category items
 type CategoryChunk struct {
 	Chunk
 	Items []Category `json:"items"`
 }
 
+// items returns the Category items of the page.
+func (c *CategoryChunk) items() []Category {
+	return c.Items
+}
+
+// NextPage fetches the page after this one with the client, or returns ErrNoPage if there is none.
+func (c *CategoryChunk) NextPage(ctx context.Context, s *Spotify) (*CategoryChunk, error) {
+	return fetchPage[CategoryChunk](ctx, s, c.Next)
+}
+
+// PreviousPage fetches the page before this one with the client, or returns ErrNoPage if there is none.
+func (c *CategoryChunk) PreviousPage(ctx context.Context, s *Spotify) (*CategoryChunk, error) {
+	return fetchPage[CategoryChunk](ctx, s, c.Previous)
+}
+
 // SimplifiedChapterChunk represents a paged set of SimplifiedChapter items
 type SimplifiedChapterChunk struct {
 	Chunk
-	Items []SimplifiedChapterChunk `json:"items"`
+	Items []SimplifiedChapter `json:"items"`
+}
+
+// items returns the SimplifiedChapter items of the page.
+func (c *SimplifiedChapterChunk) items() []SimplifiedChapter {
+	return c.Items
+}
+
+// NextPage fetches the page after this one with the client, or returns ErrNoPage if there is none.
+func (c *SimplifiedChapterChunk) NextPage(ctx context.Context, s *Spotify) (*SimplifiedChapterChunk, error) {
+	return fetchPage[SimplifiedChapterChunk](ctx, s, c.Next)
+}
+
+// PreviousPage fetches the page before this one with the client, or returns ErrNoPage if there is none.
+func (c *SimplifiedChapterChunk) PreviousPage(ctx context.Context, s *Spotify) (*SimplifiedChapterChunk, error) {
+	return fetchPage[SimplifiedChapterChunk](ctx, s, c.Previous)
 }
 
 // SimplifiedEpisodeChunk represents a paged set of SimplifiedEpisode items
@@ -58,50 +250,185 @@ type SimplifiedEpisodeChunk struct {
 	Items []SimplifiedEpisode `json:"items"`
 }
 
+// items returns the SimplifiedEpisode items of the page.
+func (c *SimplifiedEpisodeChunk) items() []SimplifiedEpisode {
+	return c.Items
+}
+
+// NextPage fetches the page after this one with the client, or returns ErrNoPage if there is none.
+func (c *SimplifiedEpisodeChunk) NextPage(ctx context.Context, s *Spotify) (*SimplifiedEpisodeChunk, error) {
+	return fetchPage[SimplifiedEpisodeChunk](ctx, s, c.Next)
+}
+
+// PreviousPage fetches the page before this one with the client, or returns ErrNoPage if there is none.
+func (c *SimplifiedEpisodeChunk) PreviousPage(ctx context.Context, s *Spotify) (*SimplifiedEpisodeChunk, error) {
+	return fetchPage[SimplifiedEpisodeChunk](ctx, s, c.Previous)
+}
+
 // SavedEpisodeChunk represents a paged set of SavedEpisode items
 type SavedEpisodeChunk struct {
 	Chunk
 	Items []SavedEpisode `json:"items"`
 }
 
+// items returns the SavedEpisode items of the page.
+func (c *SavedEpisodeChunk) items() []SavedEpisode {
+	return c.Items
+}
+
+// NextPage fetches the page after this one with the client, or returns ErrNoPage if there is none.
+func (c *SavedEpisodeChunk) NextPage(ctx context.Context, s *Spotify) (*SavedEpisodeChunk, error) {
+	return fetchPage[SavedEpisodeChunk](ctx, s, c.Next)
+}
+
+// PreviousPage fetches the page before this one with the client, or returns ErrNoPage if there is none.
+func (c *SavedEpisodeChunk) PreviousPage(ctx context.Context, s *Spotify) (*SavedEpisodeChunk, error) {
+	return fetchPage[SavedEpisodeChunk](ctx, s, c.Previous)
+}
+
 // SimplifiedPlaylistChunk represents a paged set of SimplifiedPlaylist items
 type SimplifiedPlaylistChunk struct {
 	Chunk
 	Items []SimplifiedPlaylist `json:"items"`
 }
 
+// items returns the SimplifiedPlaylist items of the page.
+func (c *SimplifiedPlaylistChunk) items() []SimplifiedPlaylist {
+	return c.Items
+}
+
+// NextPage fetches the page after this one with the client, or returns ErrNoPage if there is none.
+func (c *SimplifiedPlaylistChunk) NextPage(ctx context.Context, s *Spotify) (*SimplifiedPlaylistChunk, error) {
+	return fetchPage[SimplifiedPlaylistChunk](ctx, s, c.Next)
+}
+
+// PreviousPage fetches the page before this one with the client, or returns ErrNoPage if there is none.
+func (c *SimplifiedPlaylistChunk) PreviousPage(ctx context.Context, s *Spotify) (*SimplifiedPlaylistChunk, error) {
+	return fetchPage[SimplifiedPlaylistChunk](ctx, s, c.Previous)
+}
+
 // SimplifiedShowChunk represents a paged set of SimplifiedShow items
 type SimplifiedShowChunk struct {
 	Chunk
 	Items []SimplifiedShow `json:"items"`
 }
 
+// items returns the SimplifiedShow items of the page.
+func (c *SimplifiedShowChunk) items() []SimplifiedShow {
+	return c.Items
+}
+
+// NextPage fetches the page after this one with the client, or returns ErrNoPage if there is none.
+func (c *SimplifiedShowChunk) NextPage(ctx context.Context, s *Spotify) (*SimplifiedShowChunk, error) {
+	return fetchPage[SimplifiedShowChunk](ctx, s, c.Next)
+}
+
+// PreviousPage fetches the page before this one with the client, or returns ErrNoPage if there is none.
+func (c *SimplifiedShowChunk) PreviousPage(ctx context.Context, s *Spotify) (*SimplifiedShowChunk, error) {
+	return fetchPage[SimplifiedShowChunk](ctx, s, c.Previous)
+}
+
 // PlaylistTrackChunk represents a paged set of PlaylistTrack items
 type PlaylistTrackChunk struct {
 	Chunk
 	Items []PlaylistTrack `json:"items"`
 }
 
+// items returns the PlaylistTrack items of the page.
+func (c *PlaylistTrackChunk) items() []PlaylistTrack {
+	return c.Items
+}
+
+// NextPage fetches the page after this one with the client, or returns ErrNoPage if there is none.
+func (c *PlaylistTrackChunk) NextPage(ctx context.Context, s *Spotify) (*PlaylistTrackChunk, error) {
+	return fetchPage[PlaylistTrackChunk](ctx, s, c.Next)
+}
+
+// PreviousPage fetches the page before this one with the client, or returns ErrNoPage if there is none.
+func (c *PlaylistTrackChunk) PreviousPage(ctx context.Context, s *Spotify) (*PlaylistTrackChunk, error) {
+	return fetchPage[PlaylistTrackChunk](ctx, s, c.Previous)
+}
+
 // SimplifiedTrackChunk represents a paged set of SimplifiedTrack items
 type SimplifiedTrackChunk struct {
 	Chunk
 	Items []SimplifiedTrack `json:"items"`
 }
 
+// items returns the SimplifiedTrack items of the page.
+func (c *SimplifiedTrackChunk) items() []SimplifiedTrack {
+	return c.Items
+}
+
+// NextPage fetches the page after this one with the client, or returns ErrNoPage if there is none.
+func (c *SimplifiedTrackChunk) NextPage(ctx context.Context, s *Spotify) (*SimplifiedTrackChunk, error) {
+	return fetchPage[SimplifiedTrackChunk](ctx, s, c.Next)
+}
+
+// PreviousPage fetches the page before this one with the client, or returns ErrNoPage if there is none.
+func (c *SimplifiedTrackChunk) PreviousPage(ctx context.Context, s *Spotify) (*SimplifiedTrackChunk, error) {
+	return fetchPage[SimplifiedTrackChunk](ctx, s, c.Previous)
+}
+
 // FullTrackChunk represents a paged set of FullTrack items
 type FullTrackChunk struct {
 	Chunk
 	Items []FullTrack `json:"items"`
 }
 
+// items returns the FullTrack items of the page.
+func (c *FullTrackChunk) items() []FullTrack {
+	return c.Items
+}
+
+// NextPage fetches the page after this one with the client, or returns ErrNoPage if there is none.
+func (c *FullTrackChunk) NextPage(ctx context.Context, s *Spotify) (*FullTrackChunk, error) {
+	return fetchPage[FullTrackChunk](ctx, s, c.Next)
+}
+
+// PreviousPage fetches the page before this one with the client, or returns ErrNoPage if there is none.
+func (c *FullTrackChunk) PreviousPage(ctx context.Context, s *Spotify) (*FullTrackChunk, error) {
+	return fetchPage[FullTrackChunk](ctx, s, c.Previous)
+}
+
 // SavedTrackChunk represents a paged set of SavedTrack items
 type SavedTrackChunk struct {
 	Chunk
 	Items []SavedTrack `json:"items"`
 }
 
+// items returns the SavedTrack items of the page.
+func (c *SavedTrackChunk) items() []SavedTrack {
+	return c.Items
+}
+
+// NextPage fetches the page after this one with the client, or returns ErrNoPage if there is none.
+func (c *SavedTrackChunk) NextPage(ctx context.Context, s *Spotify) (*SavedTrackChunk, error) {
+	return fetchPage[SavedTrackChunk](ctx, s, c.Next)
+}
+
+// PreviousPage fetches the page before this one with the client, or returns ErrNoPage if there is none.
+func (c *SavedTrackChunk) PreviousPage(ctx context.Context, s *Spotify) (*SavedTrackChunk, error) {
+	return fetchPage[SavedTrackChunk](ctx, s, c.Previous)
+}
+
 // UserItemChunk represents a paged set of UserItem items
 type UserItemChunk struct {
 	Chunk
 	Items []Item
 }
+
+// items returns the Item items of the page.
+func (c *UserItemChunk) items() []Item {
+	return c.Items
+}
+
+// NextPage fetches the page after this one with the client, or returns ErrNoPage if there is none.
+func (c *UserItemChunk) NextPage(ctx context.Context, s *Spotify) (*UserItemChunk, error) {
+	return fetchPage[UserItemChunk](ctx, s, c.Next)
+}
+
+// PreviousPage fetches the page before this one with the client, or returns ErrNoPage if there is none.
+func (c *UserItemChunk) PreviousPage(ctx context.Context, s *Spotify) (*UserItemChunk, error) {
+	return fetchPage[UserItemChunk](ctx, s, c.Previous)
+}
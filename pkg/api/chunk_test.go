@@ -0,0 +1,46 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestChunkNavigation(t *testing.T) {
+	server, spotify, first, _ := testPaginator(t, "")
+	defer server.Close()
+
+	if first.HasPrevious() || !first.HasNext() {
+		t.Errorf("Expected only the next page for the first one")
+	}
+	if _, err := first.PreviousPage(context.Background(), spotify); !errors.Is(err, ErrNoPage) {
+		t.Errorf("Expected %v, got %v", ErrNoPage, err)
+	}
+
+	second, err := first.NextPage(context.Background(), spotify)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.Offset != 2 || second.Items[0].Id != "track-2" || !second.HasPrevious() {
+		t.Errorf("Unexpected second page: %+v", second.Chunk)
+	}
+
+	last, err := second.NextPage(context.Background(), spotify)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last.HasNext() || len(last.Items) != 1 || last.Items[0].Id != "track-4" {
+		t.Errorf("Unexpected last page: %+v", last.Chunk)
+	}
+	if _, err := last.NextPage(context.Background(), spotify); !errors.Is(err, ErrNoPage) {
+		t.Errorf("Expected %v, got %v", ErrNoPage, err)
+	}
+
+	previous, err := last.PreviousPage(context.Background(), spotify)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if previous.Offset != 2 || previous.Items[0].Id != "track-2" {
+		t.Errorf("Unexpected previous page: %+v", previous.Chunk)
+	}
+}
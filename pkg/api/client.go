@@ -6,23 +6,43 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
-	"strings"
+	"net/url"
+	"sync"
 
 	"golang.org/x/oauth2"
 )
 
-// Error contains the status and message that can be received from the Spotify API if the request fails.
-type Error struct {
-	Status  int    `json:"status"`
-	Message string `json:"message"`
-}
-
 // Spotify represents the Spotify API client, which provides all the functionality needed to communicate with the API.
+// It should be created with NewSpotifyClient, and is not modified after that,
+// so it can be shared between goroutines.
 type Spotify struct {
 	client *http.Client
 	// The base url of the Spotify
 	url string
+	// The policy used to retry failed requests. Requests are not retried if it is nil.
+	retry *RetryPolicy
+	// The middleware every request passes through, starting from the outermost one.
+	middleware []Middleware
+	// The source of the tokens used to authorize requests, if it is known.
+	tokenSource oauth2.TokenSource
+	// The cache of the GET responses. Responses are not cached if it is nil.
+	cache Cache
+	// The limiter pacing the requests sent to Spotify. Requests are not paced if it is nil.
+	limiter *RateLimiter
+	// The identical GET requests currently in flight. Requests are not coalesced if it is nil.
+	flights *flightGroup
+	// The logger of the requests. Requests are not logged if it is nil.
+	logger *slog.Logger
+	// The options describing how the requests are logged.
+	logOptions LogOptions
+	// The metrics of the requests. Requests are not measured if it is nil.
+	metrics Metrics
+	// The log of the mutating requests, which are recorded instead of being sent. Requests are sent if it is nil.
+	dryRun *DryRunLog
+	// The number of the batches of ids sent at the same time by the methods accepting more ids than Spotify allows.
+	batchConcurrency int
 }
 
 // spotifyRequestData is used to unify the parameters of the request functions into a single struct.
@@ -40,21 +60,32 @@ type spotifyRequestData struct {
 	// The headers used are "Content-Type:application/json" and "Content-Type:image/jpeg".
 	headers map[string]string
 	// The body of the request.
-	body io.Reader
+	// It is kept as bytes, so the request can be recreated when it is retried.
+	body []byte
 }
 
 // Get is responsible for sending GET requests with the specified endpoint and parameters.
 func (s *Spotify) Get(response interface{}, endpoint string, params ...Param) error {
+	return s.GetCtx(context.Background(), response, endpoint, params...)
+}
+
+// GetCtx is the same as Get, but it sends the request with the given context.
+func (s *Spotify) GetCtx(
+	ctx context.Context,
+	response interface{},
+	endpoint string,
+	params ...Param,
+) error {
 	requestData := spotifyRequestData{
 		response,
 		http.MethodGet,
 		endpoint,
 		params,
 		map[string]string{},
-		bytes.NewBuffer([]byte{}),
+		[]byte{},
 	}
 
-	return s.doRequest(requestData)
+	return s.doRequest(ctx, requestData)
 }
 
 // Put is responsible for sending PUT requests with the specified endpoint, body and parameters.
@@ -63,6 +94,17 @@ func (s *Spotify) Put(
 	endpoint string,
 	body []byte,
 	params ...Param,
+) error {
+	return s.PutCtx(context.Background(), response, endpoint, body, params...)
+}
+
+// PutCtx is the same as Put, but it sends the request with the given context.
+func (s *Spotify) PutCtx(
+	ctx context.Context,
+	response interface{},
+	endpoint string,
+	body []byte,
+	params ...Param,
 ) error {
 	requestData := spotifyRequestData{
 		response,
@@ -70,10 +112,10 @@ func (s *Spotify) Put(
 		endpoint,
 		params,
 		map[string]string{"Content-Type": "application/json"},
-		bytes.NewBuffer(body),
+		body,
 	}
 
-	return s.doRequest(requestData)
+	return s.doRequest(ctx, requestData)
 }
 
 // PutImage is responsible for sending PUT requests with the specified endpoint, body containing base64 encoded image and parameters.
@@ -81,6 +123,16 @@ func (s *Spotify) PutImage(
 	response interface{},
 	endpoint, body string,
 	params ...Param,
+) error {
+	return s.PutImageCtx(context.Background(), response, endpoint, body, params...)
+}
+
+// PutImageCtx is the same as PutImage, but it sends the request with the given context.
+func (s *Spotify) PutImageCtx(
+	ctx context.Context,
+	response interface{},
+	endpoint, body string,
+	params ...Param,
 ) error {
 	requestData := spotifyRequestData{
 		response,
@@ -88,10 +140,10 @@ func (s *Spotify) PutImage(
 		endpoint,
 		params,
 		map[string]string{"Content-Type": "image/jpeg"},
-		strings.NewReader(body),
+		[]byte(body),
 	}
 
-	return s.doRequest(requestData)
+	return s.doRequest(ctx, requestData)
 }
 
 // Post is responsible for sending POST requests with the specified endpoint, body and parameters.
@@ -100,6 +152,17 @@ func (s *Spotify) Post(
 	endpoint string,
 	body []byte,
 	params ...Param,
+) error {
+	return s.PostCtx(context.Background(), response, endpoint, body, params...)
+}
+
+// PostCtx is the same as Post, but it sends the request with the given context.
+func (s *Spotify) PostCtx(
+	ctx context.Context,
+	response interface{},
+	endpoint string,
+	body []byte,
+	params ...Param,
 ) error {
 	requestData := spotifyRequestData{
 		response,
@@ -107,10 +170,10 @@ func (s *Spotify) Post(
 		endpoint,
 		params,
 		map[string]string{"Content-Type": "application/json"},
-		bytes.NewBuffer(body),
+		body,
 	}
 
-	return s.doRequest(requestData)
+	return s.doRequest(ctx, requestData)
 }
 
 // Delete is responsible for sending DELETE requests with the specified endpoint, body and parameters.
@@ -119,6 +182,17 @@ func (s *Spotify) Delete(
 	endpoint string,
 	body []byte,
 	params ...Param,
+) error {
+	return s.DeleteCtx(context.Background(), response, endpoint, body, params...)
+}
+
+// DeleteCtx is the same as Delete, but it sends the request with the given context.
+func (s *Spotify) DeleteCtx(
+	ctx context.Context,
+	response interface{},
+	endpoint string,
+	body []byte,
+	params ...Param,
 ) error {
 	requestData := spotifyRequestData{
 		response,
@@ -126,77 +200,201 @@ func (s *Spotify) Delete(
 		endpoint,
 		params,
 		map[string]string{},
-		bytes.NewBuffer(body),
+		body,
 	}
 
-	return s.doRequest(requestData)
+	return s.doRequest(ctx, requestData)
 }
 
 // doRequest responsible for connecting the create and send methods.
-func (s *Spotify) doRequest(data spotifyRequestData) error {
-	req, err := s.createRequest(data)
-	if err != nil {
-		return err
-	}
+// Every attempt passes through the middleware of the client, before being sent.
+// Failed requests are repeated, as long as the retry policy of the client allows it.
+func (s *Spotify) doRequest(ctx context.Context, data spotifyRequestData) error {
+	handler := s.handler()
+	for attempt := 0; ; attempt++ {
+		req, err := newRequest(data, attempt)
+		if err != nil {
+			return err
+		}
+
+		res, err := handler(ctx, req)
+		if err == nil {
+			err = s.handleResponse(data.response, req, res)
+		}
 
-	return s.sendRequest(data.response, req)
+		delay, ok := s.retry.backoff(req.Method, req.Endpoint, attempt, err)
+		if !ok {
+			return err
+		}
+		s.logRetry(ctx, req, delay, err)
+		if s.metrics != nil {
+			s.metrics.ObserveRetry(req.Method, endpointPattern(req.Endpoint))
+		}
+		if err := wait(ctx, delay); err != nil {
+			return err
+		}
+	}
 }
 
-// createRequest responsible for creating the request with given spotifyRequestData.
-func (s *Spotify) createRequest(data spotifyRequestData) (*http.Request, error) {
+// newRequest creates the Request for the given attempt from the spotifyRequestData.
+// The query of the endpoint is merged with the params, so the middleware sees all of them in one place.
+// The endpoint is kept escaped, so the escaped segments, like the user id with the "?" in it, are sent as they are.
+func newRequest(data spotifyRequestData, attempt int) (*Request, error) {
 	endpoint, err := buildUrl(data.endpoint, data.params...)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest(data.method, s.url+endpoint, data.body)
+	parsedUrl, err := url.Parse(endpoint)
 	if err != nil {
 		return nil, err
 	}
+
+	header := http.Header{}
 	for k, v := range data.headers {
-		req.Header.Set(k, v)
+		header.Set(k, v)
 	}
-	return req, err
+	return &Request{
+		Method:   data.method,
+		Endpoint: parsedUrl.EscapedPath(),
+		Params:   parsedUrl.Query(),
+		Header:   header,
+		Body:     data.body,
+		Attempt:  attempt,
+	}, nil
 }
 
-// sendRequest sends a request to the Spotify API using the Spotify client and the created request.
-// Response data, if any, will be marshalled to the response object.
-func (s *Spotify) sendRequest(response interface{}, req *http.Request) error {
-	res, err := s.client.Do(req)
+// createRequest responsible for creating the HTTP request with given context and Request.
+func (s *Spotify) createRequest(ctx context.Context, req *Request) (*http.Request, error) {
+	endpoint := s.url + req.Endpoint
+	if len(req.Params) > 0 {
+		endpoint += "?" + req.Params.Encode()
+	}
+
+	httpReq, err := http.NewRequestWithContext(
+		ctx,
+		req.Method,
+		endpoint,
+		bytes.NewReader(req.Body),
+	)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	for k, v := range req.Header {
+		httpReq.Header[k] = v
 	}
+	return httpReq, nil
+}
 
-	body, err := io.ReadAll(res.Body)
+// sendRequest sends a request to the Spotify API using the Spotify client.
+// It is the innermost Handler of the middleware chain.
+func (s *Spotify) sendRequest(ctx context.Context, req *Request) (*Response, error) {
+	httpReq, err := s.createRequest(ctx, req)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	res, err := s.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	return &Response{
+		StatusCode: res.StatusCode,
+		Header:     res.Header,
+		Body:       res.Body,
+	}, nil
+}
+
+// handleResponse reads the response of the request and closes its body.
+// Response data, if any, will be decoded into the response object straight from the body.
+func (s *Spotify) handleResponse(response interface{}, req *Request, res *Response) error {
+	if res == nil {
+		return fmt.Errorf("spotify request error: %s %s: no response", req.Method, req.Endpoint)
 	}
 
-	if res.StatusCode != http.StatusOK {
-		return s.handleError(body)
+	if !isSuccess(res.StatusCode) {
+		body, err := res.readBody()
+		if err != nil {
+			return err
+		}
+		return s.handleError(req, res, body)
 	}
-	if response == nil {
+	if res.Body == nil {
 		return nil
 	}
-	return json.Unmarshal(body, response)
+	defer res.Body.Close()
+	return decodeResponse(res.Body, response)
 }
 
-// handleError parses the error returned by the Spotify API and formats it into the Go error.
-func (s *Spotify) handleError(body []byte) error {
-	var w struct {
-		Error Error `json:"error"`
-	}
-	err := json.Unmarshal(body, &w)
-	if err != nil {
+// The largest buffer returned to the bodyBuffers, so the buffers of the rare huge responses are not kept in memory.
+const maxPooledBody = 8 << 20
+
+// bodyBuffers holds the buffers the response bodies are read into, so the buffer is not allocated for every response.
+var bodyBuffers = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+// decodeResponse decodes the JSON body into the response object.
+// Responses such as 204 No Content have no body, so the response object is left untouched.
+//
+// The body is read into the pooled buffer and decoded from it, as the json.Decoder keeps the whole value
+// in the buffer of its own, which is allocated for every response and grows past the size of the body.
+// The buffer is reused by the following responses, so only the decoded objects are allocated.
+func decodeResponse(body io.Reader, response interface{}) error {
+	buf := bodyBuffers.Get().(*bytes.Buffer)
+	defer func() {
+		if buf.Cap() <= maxPooledBody {
+			buf.Reset()
+			bodyBuffers.Put(buf)
+		}
+	}()
+
+	if _, err := buf.ReadFrom(body); err != nil {
 		return err
 	}
-	return fmt.Errorf("spotify request error: %v", w.Error)
+	if response == nil || len(bytes.TrimSpace(buf.Bytes())) == 0 {
+		return nil
+	}
+	return json.Unmarshal(buf.Bytes(), response)
 }
 
-// NewSpotifyClient creates a Spotify client, with the appropriate Spotify base URL.
-func NewSpotifyClient(ctx context.Context, token *oauth2.Token) Spotify {
-	return Spotify{
-		oauth2.NewClient(ctx, oauth2.StaticTokenSource(token)),
-		"https://api.spotify.com/v1",
+// isSuccess checks if the status code belongs to the 2xx class.
+// Spotify uses 200, 201, 202 and 204 to report the success of the request.
+func isSuccess(status int) bool {
+	return status >= http.StatusOK && status < http.StatusMultipleChoices
+}
+
+// handleError parses the error returned by the Spotify API into the *Error.
+func (s *Spotify) handleError(req *Request, res *Response, body []byte) error {
+	apiErr := newError(res.StatusCode, res.Header, body)
+	apiErr.Method = req.Method
+	apiErr.Endpoint = req.Endpoint
+	return apiErr
+}
+
+// NewSpotifyClient creates a Spotify client, configured with the given options.
+// By default, it sends requests to the Spotify Web API base URL and uses the DefaultRetryPolicy.
+// The token is supplied with WithToken or WithTokenSource.
+//
+// The returned client is safe for concurrent use by multiple goroutines.
+func NewSpotifyClient(ctx context.Context, opts ...Option) *Spotify {
+	policy := DefaultRetryPolicy()
+	c := &clientConfig{
+		spotify: &Spotify{
+			url:              defaultBaseURL,
+			retry:            &policy,
+			logOptions:       DefaultLogOptions(),
+			batchConcurrency: defaultBatchConcurrency,
+		},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.tokenSource != nil {
+		c.spotify.tokenSource = oauth2.ReuseTokenSource(nil, c.tokenSource)
 	}
+	c.spotify.client = c.buildHTTPClient(ctx)
+	return c.spotify
 }
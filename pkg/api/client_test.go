@@ -1,11 +1,20 @@
 package api
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"path"
 	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
 )
 
 const testId = "4aawyAB9vmqN3uQ7FjRGTy"
@@ -14,12 +23,10 @@ func getTestIds() []string {
 	return []string{}
 }
 
-func testServer(handler http.HandlerFunc) (*httptest.Server, *Spotify) {
+func testServer(handler http.HandlerFunc, opts ...Option) (*httptest.Server, *Spotify) {
 	server := httptest.NewServer(handler)
-	spotify := &Spotify{
-		client: http.DefaultClient,
-		url:    server.URL,
-	}
+	opts = append([]Option{WithBaseURL(server.URL), WithRetryPolicy(RetryPolicy{})}, opts...)
+	spotify := NewSpotifyClient(context.Background(), opts...)
 
 	return server, spotify
 }
@@ -104,3 +111,213 @@ func writeResponse(w http.ResponseWriter, body []byte) error {
 	_, err := w.Write(body)
 	return err
 }
+
+func TestGetCtxCanceled(t *testing.T) {
+	server, spotify := testServer(testHandler())
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := spotify.GetTrackCtx(ctx, testId)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected %v, got %v", context.Canceled, err)
+	}
+}
+
+func testStatusHandler(status int, body []byte) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		if status == http.StatusNoContent {
+			return
+		}
+		_, err := w.Write(body)
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+func TestSuccessStatuses(t *testing.T) {
+	body, err := os.ReadFile("testdata/snapshot.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	calls := []struct {
+		name string
+		call func(s *Spotify) error
+	}{
+		{"CreatePlaylist", func(s *Spotify) error {
+			return s.CreatePlaylist(testId, Name("test"), []Property{})
+		}},
+		{"AddItemsToPlaylist", func(s *Spotify) error {
+			_, err := s.AddItemsToPlaylist(testId, []Property{})
+			return err
+		}},
+		{"UpdatePlaylistItems", func(s *Spotify) error {
+			_, err := s.UpdatePlaylistItems(testId, []Property{})
+			return err
+		}},
+		{"RemovePlaylistItem", func(s *Spotify) error {
+			_, err := s.RemovePlaylistItem(testId, []Property{})
+			return err
+		}},
+		{"AddCustomPlaylistCoverImage", func(s *Spotify) error {
+			return s.AddCustomPlaylistCoverImage(testId, "image")
+		}},
+		{"ChangePlaylistDetails", func(s *Spotify) error {
+			return s.ChangePlaylistDetails(testId, []Property{})
+		}},
+		{"StartResumePlayback", func(s *Spotify) error {
+			return s.StartResumePlayback([]Property{})
+		}},
+		{"PausePlayback", func(s *Spotify) error {
+			return s.PausePlayback()
+		}},
+		{"SkipToNext", func(s *Spotify) error {
+			return s.SkipToNext()
+		}},
+		{"AddItemToPlaybackQueue", func(s *Spotify) error {
+			return s.AddItemToPlaybackQueue("spotify:track:" + testId)
+		}},
+		{"SaveTracksForCurrentUser", func(s *Spotify) error {
+			return s.SaveTracksForCurrentUser(getTestIds())
+		}},
+		{"RemoveUserSavedTracks", func(s *Spotify) error {
+			return s.RemoveUserSavedTracks(getTestIds())
+		}},
+		{"FollowPlaylist", func(s *Spotify) error {
+			return s.FollowPlaylist(testId, []Property{})
+		}},
+		{"UnfollowPlaylist", func(s *Spotify) error {
+			return s.UnfollowPlaylist(testId)
+		}},
+	}
+
+	statuses := []int{
+		http.StatusOK,
+		http.StatusCreated,
+		http.StatusAccepted,
+		http.StatusNoContent,
+	}
+
+	for _, status := range statuses {
+		for _, c := range calls {
+			t.Run(fmt.Sprintf("%s/%d", c.name, status), func(t *testing.T) {
+				server, spotify := testServer(testStatusHandler(status, body))
+				defer server.Close()
+
+				if err := c.call(spotify); err != nil {
+					t.Fatal(err)
+				}
+			})
+		}
+	}
+}
+
+func TestEmptyBodyWithResponse(t *testing.T) {
+	server, spotify := testServer(testStatusHandler(http.StatusNoContent, nil))
+	defer server.Close()
+
+	playback, err := spotify.GetPlaybackState()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(&Playback{}, playback); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestRedirectStatusIsError(t *testing.T) {
+	server, spotify := testServer(testStatusHandler(http.StatusNotModified, nil))
+	defer server.Close()
+
+	var apiErr *Error
+	err := spotify.PausePlayback()
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusNotModified {
+		t.Fatalf("Expected *Error with status %d, got %v", http.StatusNotModified, err)
+	}
+}
+
+var decodeBenchmarks = []struct {
+	file     string
+	response func() interface{}
+}{
+	{"trackAudioAnalysis.json", func() interface{} { return &AudioAnalysis{} }},
+	{"playlist.json", func() interface{} { return &FullPlaylist{} }},
+	{"playlistItems.json", func() interface{} { return &PlaylistTrackChunk{} }},
+	{"search.json", func() interface{} { return &SearchResult{} }},
+}
+
+// testLargeFixture repeats every array of the fixture the given number of times,
+// turning it into the large response, such as the audio analysis of a long track.
+func testLargeFixture(b *testing.B, body []byte, times int) []byte {
+	fixture := map[string]interface{}{}
+	if err := json.Unmarshal(body, &fixture); err != nil {
+		b.Fatal(err)
+	}
+	for k, v := range fixture {
+		items, ok := v.([]interface{})
+		if !ok {
+			continue
+		}
+		large := make([]interface{}, 0, len(items)*times)
+		for i := 0; i < times; i++ {
+			large = append(large, items...)
+		}
+		fixture[k] = large
+	}
+
+	large, err := json.Marshal(fixture)
+	if err != nil {
+		b.Fatal(err)
+	}
+	return large
+}
+
+// benchmarkDecode compares decoding the body with decodeResponse to decoding it with the json.Decoder,
+// and to reading it whole with io.ReadAll before decoding it with json.Unmarshal.
+func benchmarkDecode(b *testing.B, name string, body []byte, response func() interface{}) {
+	b.Run(name+"/pooled", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := decodeResponse(bytes.NewReader(body), response()); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run(name+"/decoder", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := json.NewDecoder(bytes.NewReader(body)).Decode(response()); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run(name+"/readAll", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			data, err := io.ReadAll(bytes.NewReader(body))
+			if err != nil {
+				b.Fatal(err)
+			}
+			if err := json.Unmarshal(data, response()); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkDecodeResponse(b *testing.B) {
+	for _, bench := range decodeBenchmarks {
+		body, err := os.ReadFile("testdata/" + bench.file)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		benchmarkDecode(b, bench.file, body, bench.response)
+		benchmarkDecode(b, bench.file+"/large", testLargeFixture(b, body, 1000), bench.response)
+	}
+}
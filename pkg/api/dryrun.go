@@ -0,0 +1,84 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// DryRunSnapshotId is the snapshot id returned by the mutating playlist requests in the dry-run mode.
+// It is never returned by Spotify, so the synthetic snapshots are easy to tell apart from the real ones.
+const DryRunSnapshotId = "dry-run"
+
+// DryRunEntry is the mutating request, which was recorded instead of being sent in the dry-run mode.
+type DryRunEntry struct {
+	// The method of the request: PUT, POST or DELETE.
+	Method string
+	// The endpoint of the request, without the query.
+	Endpoint string
+	// The params of the request.
+	Params url.Values
+	// The decoded JSON body of the request, or nil if the body was empty.
+	// Bodies, which are not JSON, such as the base64 encoded images, are kept as strings.
+	Body interface{}
+}
+
+// DryRunLog records the mutating requests of the Spotify client in the dry-run mode.
+// It is safe for concurrent use by multiple goroutines.
+type DryRunLog struct {
+	mu      sync.Mutex
+	entries []DryRunEntry
+}
+
+// NewDryRunLog creates the empty DryRunLog.
+func NewDryRunLog() *DryRunLog {
+	return &DryRunLog{}
+}
+
+// Entries returns the requests recorded so far, in order they were made.
+func (l *DryRunLog) Entries() []DryRunEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]DryRunEntry(nil), l.entries...)
+}
+
+// Reset removes all the recorded requests.
+func (l *DryRunLog) Reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = nil
+}
+
+// record adds the request to the log.
+func (l *DryRunLog) record(req *Request) {
+	entry := DryRunEntry{
+		Method:   req.Method,
+		Endpoint: req.Endpoint,
+		Params:   req.Params,
+	}
+	if len(req.Body) > 0 {
+		if err := json.Unmarshal(req.Body, &entry.Body); err != nil {
+			entry.Body = string(req.Body)
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, entry)
+}
+
+// dryRunMiddleware records the PUT, POST and DELETE requests in the DryRunLog of the client, instead of sending them.
+// They are answered with the synthetic response, holding the DryRunSnapshotId.
+// GET requests are sent as usual.
+func (s *Spotify) dryRunMiddleware(next Handler) Handler {
+	return func(ctx context.Context, req *Request) (*Response, error) {
+		if req.Method == http.MethodGet {
+			return next(ctx, req)
+		}
+
+		s.dryRun.record(req)
+		return NewResponse(http.StatusOK, []byte(`{"snapshot_id":"`+DryRunSnapshotId+`"}`)), nil
+	}
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"sync/atomic"
+	"testing"
+)
+
+func TestDryRun(t *testing.T) {
+	var calls int32
+	var methods []string
+	log := NewDryRunLog()
+	server, spotify := testServer(
+		func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			methods = append(methods, r.Method)
+			err := writeResponse(w, []byte(`{"id":"`+testId+`"}`))
+			if err != nil {
+				panic(err)
+			}
+		},
+		WithDryRun(log),
+	)
+	defer server.Close()
+
+	track, err := spotify.GetTrack(testId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if track.Id != testId {
+		t.Errorf("Expected GET request to be sent, got %s", track.Id)
+	}
+
+	snapshot, err := spotify.AddItemsToPlaylist(testId, []Property{PropertyURIs([]string{"spotify:track:" + testId})})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snapshot.SnapshotId != DryRunSnapshotId {
+		t.Errorf("Expected synthetic snapshot, got %s", snapshot.SnapshotId)
+	}
+	if err := spotify.PausePlayback(DeviceId("device")); err != nil {
+		t.Fatal(err)
+	}
+	if err := spotify.CreatePlaylist(testId, Name("test"), []Property{Public(false)}); err != nil {
+		t.Fatal(err)
+	}
+	if err := spotify.AddCustomPlaylistCoverImage(testId, "aW1hZ2U="); err != nil {
+		t.Fatal(err)
+	}
+
+	if calls != 1 || !reflect.DeepEqual(methods, []string{http.MethodGet}) {
+		t.Errorf("Expected only the GET request to be sent, got %v", methods)
+	}
+
+	entries := log.Entries()
+	expected := []DryRunEntry{
+		{
+			Method:   http.MethodPut,
+			Endpoint: "/playlists/" + testId + "/tracks",
+			Params:   url.Values{},
+			Body:     map[string]interface{}{"uris": []interface{}{"spotify:track:" + testId}},
+		},
+		{
+			Method:   http.MethodPut,
+			Endpoint: "/me/player/pause",
+			Params:   url.Values{"device_id": {"device"}},
+		},
+		{
+			Method:   http.MethodPost,
+			Endpoint: "/users/" + testId + "/playlists",
+			Params:   url.Values{},
+			Body:     map[string]interface{}{"public": false},
+		},
+		{
+			Method:   http.MethodPut,
+			Endpoint: "/playlists/" + testId + "/images",
+			Params:   url.Values{},
+			Body:     "aW1hZ2U=",
+		},
+	}
+	if !reflect.DeepEqual(entries, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, entries)
+	}
+
+	log.Reset()
+	if len(log.Entries()) != 0 {
+		t.Errorf("Expected empty log after reset")
+	}
+}
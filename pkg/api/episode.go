@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"strings"
 )
@@ -57,8 +58,17 @@ type SavedEpisode struct {
 //
 // Scopes: ScopeUserReadPlaybackPosition.
 func (s *Spotify) GetEpisode(id string, params ...Param) (*FullEpisode, error) {
+	return s.GetEpisodeCtx(context.Background(), id, params...)
+}
+
+// GetEpisodeCtx is the same as GetEpisode, but it sends the request with the given context.
+func (s *Spotify) GetEpisodeCtx(
+	ctx context.Context,
+	id string,
+	params ...Param,
+) (*FullEpisode, error) {
 	episode := &FullEpisode{}
-	err := s.Get(episode, fmt.Sprintf("/episodes/%s", id), params...)
+	err := s.GetCtx(ctx, episode, fmt.Sprintf("/episodes/%s", id), params...)
 	return episode, err
 }
 
@@ -68,11 +78,22 @@ func (s *Spotify) GetEpisode(id string, params ...Param) (*FullEpisode, error) {
 //
 // Scopes: ScopeUserReadPlaybackPosition.
 func (s *Spotify) GetEpisodes(ids []string, params ...Param) ([]*FullEpisode, error) {
-	var w struct {
-		Episodes []*FullEpisode `json:"episodes"`
-	}
-	err := s.Get(&w, fmt.Sprintf("/episodes?ids=%s", strings.Join(ids, ",")), params...)
-	return w.Episodes, err
+	return s.GetEpisodesCtx(context.Background(), ids, params...)
+}
+
+// GetEpisodesCtx is the same as GetEpisodes, but it sends the request with the given context.
+func (s *Spotify) GetEpisodesCtx(
+	ctx context.Context,
+	ids []string,
+	params ...Param,
+) ([]*FullEpisode, error) {
+	return batchIds(ctx, s, ids, maxEpisodeIds, func(ctx context.Context, ids []string) ([]*FullEpisode, error) {
+		var w struct {
+			Episodes []*FullEpisode `json:"episodes"`
+		}
+		err := s.GetCtx(ctx, &w, fmt.Sprintf("/episodes?ids=%s", strings.Join(ids, ",")), params...)
+		return w.Episodes, err
+	})
 }
 
 // GetUserSavedEpisodes obtains a list of the episodes saved in the current Spotify user's library.
@@ -82,8 +103,16 @@ func (s *Spotify) GetEpisodes(ids []string, params ...Param) ([]*FullEpisode, er
 //
 // Scopes: ScopeUserLibraryRead, UserReadPlaybackPosition.
 func (s *Spotify) GetUserSavedEpisodes(params ...Param) (*SavedEpisodeChunk, error) {
+	return s.GetUserSavedEpisodesCtx(context.Background(), params...)
+}
+
+// GetUserSavedEpisodesCtx is the same as GetUserSavedEpisodes, but it sends the request with the given context.
+func (s *Spotify) GetUserSavedEpisodesCtx(
+	ctx context.Context,
+	params ...Param,
+) (*SavedEpisodeChunk, error) {
 	episodeChunk := &SavedEpisodeChunk{}
-	err := s.Get(episodeChunk, "/me/episodes", params...)
+	err := s.GetCtx(ctx, episodeChunk, "/me/episodes", params...)
 	return episodeChunk, err
 }
 
@@ -92,7 +121,14 @@ func (s *Spotify) GetUserSavedEpisodes(params ...Param) (*SavedEpisodeChunk, err
 //
 // Scopes: ScopeUserLibraryModify.
 func (s *Spotify) SaveEpisodesForCurrentUser(ids []string) error {
-	return s.Put(nil, fmt.Sprintf("/me/episodes?ids=%s", strings.Join(ids, ",")), []byte{})
+	return s.SaveEpisodesForCurrentUserCtx(context.Background(), ids)
+}
+
+// SaveEpisodesForCurrentUserCtx is the same as SaveEpisodesForCurrentUser, but it sends the request with the given context.
+func (s *Spotify) SaveEpisodesForCurrentUserCtx(ctx context.Context, ids []string) error {
+	return batchEach(ctx, s, ids, maxSavedEpisodeIds, func(ctx context.Context, ids []string) error {
+		return s.PutCtx(ctx, nil, fmt.Sprintf("/me/episodes?ids=%s", strings.Join(ids, ",")), []byte{})
+	})
 }
 
 // RemoveUserSavedEpisodes removes one or more episodes from the current user's library.
@@ -100,7 +136,14 @@ func (s *Spotify) SaveEpisodesForCurrentUser(ids []string) error {
 //
 // Scopes: ScopeUserLibraryModify.
 func (s *Spotify) RemoveUserSavedEpisodes(ids []string) error {
-	return s.Delete(nil, fmt.Sprintf("/me/episodes?ids=%s", strings.Join(ids, ",")), []byte{})
+	return s.RemoveUserSavedEpisodesCtx(context.Background(), ids)
+}
+
+// RemoveUserSavedEpisodesCtx is the same as RemoveUserSavedEpisodes, but it sends the request with the given context.
+func (s *Spotify) RemoveUserSavedEpisodesCtx(ctx context.Context, ids []string) error {
+	return batchEach(ctx, s, ids, maxSavedEpisodeIds, func(ctx context.Context, ids []string) error {
+		return s.DeleteCtx(ctx, nil, fmt.Sprintf("/me/episodes?ids=%s", strings.Join(ids, ",")), []byte{})
+	})
 }
 
 // CheckUserSavedEpisodes checks if one or more episodes is already saved in the current Spotify user's 'Your Episodes' library.
@@ -108,10 +151,18 @@ func (s *Spotify) RemoveUserSavedEpisodes(ids []string) error {
 //
 // Scopes: ScopeUserLibraryRead.
 func (s *Spotify) CheckUserSavedEpisodes(ids []string) ([]bool, error) {
-	containmentInfo := []bool{}
-	err := s.Get(
-		&containmentInfo,
-		fmt.Sprintf("/me/episodes/contains?ids=%s", strings.Join(ids, ",")),
-	)
-	return containmentInfo, err
+	return s.CheckUserSavedEpisodesCtx(context.Background(), ids)
+}
+
+// CheckUserSavedEpisodesCtx is the same as CheckUserSavedEpisodes, but it sends the request with the given context.
+func (s *Spotify) CheckUserSavedEpisodesCtx(ctx context.Context, ids []string) ([]bool, error) {
+	return batchIds(ctx, s, ids, maxSavedEpisodeIds, func(ctx context.Context, ids []string) ([]bool, error) {
+		containmentInfo := []bool{}
+		err := s.GetCtx(
+			ctx,
+			&containmentInfo,
+			fmt.Sprintf("/me/episodes/contains?ids=%s", strings.Join(ids, ",")),
+		)
+		return containmentInfo, err
+	})
 }
@@ -0,0 +1,161 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Sentinel errors, which can be used with errors.Is to check the kind of the failed request.
+var (
+	// ErrNotFound is matched by errors with the 404 Not Found status.
+	ErrNotFound = errors.New("spotify: not found")
+	// ErrUnauthorized is matched by errors with the 401 Unauthorized status.
+	ErrUnauthorized = errors.New("spotify: unauthorized")
+	// ErrRateLimited is matched by errors with the 429 Too Many Requests status.
+	ErrRateLimited = errors.New("spotify: rate limited")
+	// ErrNoActiveDevice is matched by player errors with the NO_ACTIVE_DEVICE reason.
+	ErrNoActiveDevice = errors.New("spotify: no active device")
+	// ErrPremiumRequired is matched by player errors with the PREMIUM_REQUIRED reason.
+	ErrPremiumRequired = errors.New("spotify: premium required")
+	// ErrNoPage is returned by NextPage and PreviousPage of the chunk, which has no such page.
+	ErrNoPage = errors.New("spotify: no page")
+)
+
+// Reasons, which can be returned by the Spotify API if the player request fails.
+const (
+	// The command requires a previous track, but there is none in the context.
+	ReasonNoPrevTrack = "NO_PREV_TRACK"
+	// The command requires a next track, but there is none in the context.
+	ReasonNoNextTrack = "NO_NEXT_TRACK"
+	// The requested track does not exist.
+	ReasonNoSpecificTrack = "NO_SPECIFIC_TRACK"
+	// The command requires playback to not be paused.
+	ReasonAlreadyPaused = "ALREADY_PAUSED"
+	// The command requires playback to be paused.
+	ReasonNotPaused = "NOT_PAUSED"
+	// The command requires playback on the local device.
+	ReasonNotPlayingLocally = "NOT_PLAYING_LOCALLY"
+	// The command requires that a track is currently playing.
+	ReasonNotPlayingTrack = "NOT_PLAYING_TRACK"
+	// The command requires that a context is currently playing.
+	ReasonNotPlayingContext = "NOT_PLAYING_CONTEXT"
+	// The shuffle command cannot be applied on an endless context.
+	ReasonEndlessContext = "ENDLESS_CONTEXT"
+	// The command could not be performed on the context.
+	ReasonContextDisallow = "CONTEXT_DISALLOW"
+	// The track should not be restarted if the same track and context is already playing,
+	// and there is a resume point.
+	ReasonAlreadyPlaying = "ALREADY_PLAYING"
+	// The user is rate limited due to too frequent track play, also known as cat-on-the-keyboard spamming.
+	ReasonRateLimited = "RATE_LIMITED"
+	// The context cannot be remote-controlled.
+	ReasonRemoteControlDisallow = "REMOTE_CONTROL_DISALLOW"
+	// Not possible to remote control the device.
+	ReasonDeviceNotControllable = "DEVICE_NOT_CONTROLLABLE"
+	// Not possible to remote control the device's volume.
+	ReasonVolumeControlDisallow = "VOLUME_CONTROL_DISALLOW"
+	// Requires an active device and the user has none.
+	ReasonNoActiveDevice = "NO_ACTIVE_DEVICE"
+	// The request is prohibited for non-premium users.
+	ReasonPremiumRequired = "PREMIUM_REQUIRED"
+	// Certain actions are restricted because of unknown reasons.
+	ReasonUnknown = "UNKNOWN"
+)
+
+// Error contains the status and message that can be received from the Spotify API if the request fails.
+// It can be obtained from the returned error with errors.As,
+// and matched against the sentinel errors, such as ErrNotFound, with errors.Is.
+type Error struct {
+	// The HTTP status code of the response.
+	Status int `json:"status"`
+	// A short description of the cause of the error.
+	Message string `json:"message"`
+	// The reason of the failed player request. Empty for other endpoints.
+	Reason string `json:"reason"`
+	// The method of the failed request.
+	Method string `json:"-"`
+	// The endpoint of the failed request, without query parameters.
+	Endpoint string `json:"-"`
+	// The time to wait before retrying the request, taken from the Retry-After header.
+	RetryAfter time.Duration `json:"-"`
+}
+
+// Error formats the Spotify API error into the readable message.
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("spotify request error: %s %s: %d", e.Method, e.Endpoint, e.Status)
+	if e.Message != "" {
+		msg += " " + e.Message
+	}
+	if e.Reason != "" {
+		msg += fmt.Sprintf(" (%s)", e.Reason)
+	}
+	return msg
+}
+
+// Is reports whether the error matches the given sentinel error.
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.Status == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.Status == http.StatusUnauthorized
+	case ErrRateLimited:
+		return e.Status == http.StatusTooManyRequests
+	case ErrNoActiveDevice:
+		return e.Reason == ReasonNoActiveDevice
+	case ErrPremiumRequired:
+		return e.Reason == ReasonPremiumRequired
+	}
+	return false
+}
+
+// newError creates the Error from the status, headers and body of the failed response.
+// Besides the regular error object, it supports the authentication error format
+// and bodies, which are not JSON at all.
+func newError(status int, header http.Header, body []byte) *Error {
+	apiErr := &Error{
+		Status:     status,
+		RetryAfter: parseRetryAfter(header.Get("Retry-After")),
+	}
+
+	var w struct {
+		Error       json.RawMessage `json:"error"`
+		Description string          `json:"error_description"`
+	}
+	if err := json.Unmarshal(body, &w); err != nil || len(w.Error) == 0 {
+		apiErr.Message = strings.TrimSpace(string(body))
+	} else if err := json.Unmarshal(w.Error, apiErr); err != nil {
+		var code string
+		_ = json.Unmarshal(w.Error, &code)
+		apiErr.Message = strings.TrimSpace(code + ": " + w.Description)
+	}
+
+	// The status in the body is informational, the one of the response is the source of truth.
+	apiErr.Status = status
+	if apiErr.Message == "" {
+		apiErr.Message = http.StatusText(status)
+	}
+	return apiErr
+}
+
+// parseRetryAfter parses the value of the Retry-After header,
+// which can be either a number of seconds or an HTTP date.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if d := time.Until(date); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
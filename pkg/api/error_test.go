@@ -0,0 +1,163 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func testErrorHandler(status int, header http.Header, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		for k, v := range header {
+			w.Header()[k] = v
+		}
+		w.WriteHeader(status)
+		_, err := w.Write([]byte(body))
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+func TestError(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		header   http.Header
+		body     string
+		expected Error
+		sentinel error
+	}{
+		{
+			name:   "not found",
+			status: http.StatusNotFound,
+			body:   `{"error":{"status":404,"message":"Non existing id"}}`,
+			expected: Error{
+				Status:   http.StatusNotFound,
+				Message:  "Non existing id",
+				Method:   http.MethodGet,
+				Endpoint: "/tracks/" + testId,
+			},
+			sentinel: ErrNotFound,
+		},
+		{
+			name:   "unauthorized",
+			status: http.StatusUnauthorized,
+			body:   `{"error":{"status":401,"message":"The access token expired"}}`,
+			expected: Error{
+				Status:   http.StatusUnauthorized,
+				Message:  "The access token expired",
+				Method:   http.MethodGet,
+				Endpoint: "/tracks/" + testId,
+			},
+			sentinel: ErrUnauthorized,
+		},
+		{
+			name:   "rate limited",
+			status: http.StatusTooManyRequests,
+			header: http.Header{"Retry-After": []string{"7"}},
+			body:   `{"error":{"status":429,"message":"API rate limit exceeded"}}`,
+			expected: Error{
+				Status:     http.StatusTooManyRequests,
+				Message:    "API rate limit exceeded",
+				Method:     http.MethodGet,
+				Endpoint:   "/tracks/" + testId,
+				RetryAfter: 7 * time.Second,
+			},
+			sentinel: ErrRateLimited,
+		},
+		{
+			name:   "no active device",
+			status: http.StatusNotFound,
+			body:   `{"error":{"status":404,"message":"Player command failed: No active device found","reason":"NO_ACTIVE_DEVICE"}}`,
+			expected: Error{
+				Status:   http.StatusNotFound,
+				Message:  "Player command failed: No active device found",
+				Reason:   ReasonNoActiveDevice,
+				Method:   http.MethodGet,
+				Endpoint: "/tracks/" + testId,
+			},
+			sentinel: ErrNoActiveDevice,
+		},
+		{
+			name:   "premium required",
+			status: http.StatusForbidden,
+			body:   `{"error":{"status":403,"message":"Player command failed: Premium required","reason":"PREMIUM_REQUIRED"}}`,
+			expected: Error{
+				Status:   http.StatusForbidden,
+				Message:  "Player command failed: Premium required",
+				Reason:   ReasonPremiumRequired,
+				Method:   http.MethodGet,
+				Endpoint: "/tracks/" + testId,
+			},
+			sentinel: ErrPremiumRequired,
+		},
+		{
+			name:   "authentication error",
+			status: http.StatusBadRequest,
+			body:   `{"error":"invalid_client","error_description":"Invalid client"}`,
+			expected: Error{
+				Status:   http.StatusBadRequest,
+				Message:  "invalid_client: Invalid client",
+				Method:   http.MethodGet,
+				Endpoint: "/tracks/" + testId,
+			},
+		},
+		{
+			name:   "non json body",
+			status: http.StatusBadGateway,
+			body:   "<html>Bad Gateway</html>\n",
+			expected: Error{
+				Status:   http.StatusBadGateway,
+				Message:  "<html>Bad Gateway</html>",
+				Method:   http.MethodGet,
+				Endpoint: "/tracks/" + testId,
+			},
+		},
+		{
+			name:   "empty body",
+			status: http.StatusServiceUnavailable,
+			expected: Error{
+				Status:   http.StatusServiceUnavailable,
+				Message:  http.StatusText(http.StatusServiceUnavailable),
+				Method:   http.MethodGet,
+				Endpoint: "/tracks/" + testId,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, spotify := testServer(testErrorHandler(tt.status, tt.header, tt.body))
+			defer server.Close()
+
+			_, err := spotify.GetTrack(testId)
+			var apiErr *Error
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("Expected *Error, got %v", err)
+			}
+			if *apiErr != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, *apiErr)
+			}
+			if tt.sentinel != nil && !errors.Is(err, tt.sentinel) {
+				t.Errorf("Expected error to match %v", tt.sentinel)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if d := parseRetryAfter("3"); d != 3*time.Second {
+		t.Errorf("Expected %v, got %v", 3*time.Second, d)
+	}
+
+	date := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	if d := parseRetryAfter(date); d <= 0 || d > time.Minute {
+		t.Errorf("Expected duration up to %v, got %v", time.Minute, d)
+	}
+
+	if d := parseRetryAfter("soon"); d != 0 {
+		t.Errorf("Expected 0, got %v", d)
+	}
+}
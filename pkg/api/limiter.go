@@ -0,0 +1,221 @@
+package api
+
+import (
+	"container/heap"
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Priority decides the order, in which the requests waiting for the RateLimiter are sent.
+// Requests with the higher priority are sent first, requests with the same priority are sent in order of arrival.
+type Priority int
+
+// Priorities, which can be attached to the context with WithPriority.
+const (
+	// PriorityLow is intended for the background work, such as crawling the library.
+	PriorityLow Priority = -1
+	// PriorityNormal is used for requests without the priority in their context.
+	PriorityNormal Priority = 0
+	// PriorityHigh is intended for interactive commands, such as controlling the player.
+	// It is used for the /me/player endpoints without the priority in their context.
+	PriorityHigh Priority = 1
+)
+
+// priorityKey is the context key of the request priority.
+type priorityKey struct{}
+
+// WithPriority returns the copy of the context, which makes the requests sent with it use the given priority.
+func WithPriority(ctx context.Context, priority Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, priority)
+}
+
+// requestPriority returns the priority of the request, taken from its context.
+// Player commands are interactive, so they are prioritized unless the context says otherwise.
+func requestPriority(ctx context.Context, req *Request) Priority {
+	if priority, ok := ctx.Value(priorityKey{}).(Priority); ok {
+		return priority
+	}
+	if req.Endpoint == "/me/player" || strings.HasPrefix(req.Endpoint, "/me/player/") {
+		return PriorityHigh
+	}
+	return PriorityNormal
+}
+
+// RateLimiter paces the requests using the token bucket, so the client stays under the rate limit of Spotify,
+// instead of only reacting to 429 responses.
+// The same RateLimiter can be shared by several clients, using the credentials of the same app.
+// It is safe for concurrent use by multiple goroutines.
+// It is recommended to create RateLimiter through the NewRateLimiter function.
+type RateLimiter struct {
+	mu sync.Mutex
+	// The number of tokens added to the bucket every second.
+	rate float64
+	// The capacity of the bucket, which is the number of requests that can be sent at once.
+	burst float64
+	// The number of tokens currently in the bucket.
+	tokens float64
+	// The time the bucket was last refilled.
+	last time.Time
+	// The requests waiting for the token.
+	queue waiterQueue
+	// The number of waiters created so far, used to keep the order of arrival.
+	seq uint64
+	// Whether the goroutine handing tokens to the waiters is running.
+	dispatching bool
+}
+
+// NewRateLimiter creates the RateLimiter, which allows rate requests per second on average,
+// and up to burst requests at once. The bucket starts full.
+// Non-positive rate disables the limit.
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until the request with the given priority can be sent, or until the context is done.
+func (l *RateLimiter) Wait(ctx context.Context, priority Priority) error {
+	if l.rate <= 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	l.refill(time.Now())
+	if len(l.queue) == 0 && l.tokens >= 1 {
+		l.tokens--
+		l.mu.Unlock()
+		return nil
+	}
+
+	w := &waiter{priority: priority, seq: l.seq, ready: make(chan struct{})}
+	l.seq++
+	heap.Push(&l.queue, w)
+	if !l.dispatching {
+		l.dispatching = true
+		go l.dispatch()
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if w.index >= 0 {
+			heap.Remove(&l.queue, w.index)
+		} else {
+			// The token was granted after the context was done, so it is returned to the bucket.
+			l.tokens++
+		}
+		return ctx.Err()
+	}
+}
+
+// QueueDepth returns the number of requests currently waiting for the token.
+func (l *RateLimiter) QueueDepth() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.queue)
+}
+
+// dispatch hands the tokens to the waiters as they become available, starting from the highest priority.
+// It stops once the queue is empty.
+func (l *RateLimiter) dispatch() {
+	for {
+		l.mu.Lock()
+		if len(l.queue) == 0 {
+			l.dispatching = false
+			l.mu.Unlock()
+			return
+		}
+
+		l.refill(time.Now())
+		if l.tokens >= 1 {
+			l.tokens--
+			w := heap.Pop(&l.queue).(*waiter)
+			close(w.ready)
+			l.mu.Unlock()
+			continue
+		}
+
+		delay := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+		l.mu.Unlock()
+		time.Sleep(delay)
+	}
+}
+
+// refill adds the tokens accumulated since the last refill to the bucket.
+func (l *RateLimiter) refill(now time.Time) {
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+}
+
+// limiterMiddleware makes every request sent to Spotify wait for the RateLimiter of the client.
+// It is placed right before the request is sent, so the responses served from the cache are not limited.
+func (s *Spotify) limiterMiddleware(next Handler) Handler {
+	return func(ctx context.Context, req *Request) (*Response, error) {
+		start := time.Now()
+		if err := s.limiter.Wait(ctx, requestPriority(ctx, req)); err != nil {
+			return nil, err
+		}
+		if s.metrics != nil {
+			s.metrics.ObserveRateLimitWait(time.Since(start))
+		}
+		return next(ctx, req)
+	}
+}
+
+// waiter is the request waiting for the token of the RateLimiter.
+type waiter struct {
+	priority Priority
+	seq      uint64
+	// Closed once the token is granted.
+	ready chan struct{}
+	// The position in the queue, or -1 once the waiter leaves it.
+	index int
+}
+
+// waiterQueue orders the waiters by priority, and then by arrival. It implements heap.Interface.
+type waiterQueue []*waiter
+
+func (q waiterQueue) Len() int { return len(q) }
+
+func (q waiterQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority > q[j].priority
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q waiterQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *waiterQueue) Push(x any) {
+	w := x.(*waiter)
+	w.index = len(*q)
+	*q = append(*q, w)
+}
+
+func (q *waiterQueue) Pop() any {
+	old := *q
+	w := old[len(old)-1]
+	old[len(old)-1] = nil
+	w.index = -1
+	*q = old[:len(old)-1]
+	return w
+}
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func waitQueueDepth(t *testing.T, limiter *RateLimiter, depth int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for limiter.QueueDepth() != depth {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected queue depth %d, got %d", depth, limiter.QueueDepth())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestRateLimiterPriority(t *testing.T) {
+	limiter := NewRateLimiter(20, 1)
+	if err := limiter.Wait(context.Background(), PriorityNormal); err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	var order []Priority
+	var wg sync.WaitGroup
+	enqueue := func(priority Priority) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := limiter.Wait(context.Background(), priority); err != nil {
+				t.Error(err)
+			}
+			mu.Lock()
+			order = append(order, priority)
+			mu.Unlock()
+		}()
+	}
+
+	enqueue(PriorityLow)
+	waitQueueDepth(t, limiter, 1)
+	enqueue(PriorityNormal)
+	waitQueueDepth(t, limiter, 2)
+	enqueue(PriorityHigh)
+	waitQueueDepth(t, limiter, 3)
+	wg.Wait()
+
+	expected := []Priority{PriorityHigh, PriorityNormal, PriorityLow}
+	if !reflect.DeepEqual(order, expected) {
+		t.Errorf("Expected %v, got %v", expected, order)
+	}
+	if limiter.QueueDepth() != 0 {
+		t.Errorf("Expected empty queue, got %d", limiter.QueueDepth())
+	}
+}
+
+func TestRateLimiterCanceled(t *testing.T) {
+	limiter := NewRateLimiter(1, 1)
+	if err := limiter.Wait(context.Background(), PriorityNormal); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := limiter.Wait(ctx, PriorityNormal)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if limiter.QueueDepth() != 0 {
+		t.Errorf("Expected canceled request to leave the queue, got %d", limiter.QueueDepth())
+	}
+}
+
+func TestRateLimiterClient(t *testing.T) {
+	calls := 0
+	header := http.Header{"Cache-Control": []string{"max-age=60"}}
+	limiter := NewRateLimiter(20, 1)
+	server, spotify := testServer(
+		testCacheHandler(&calls, header, []byte(`{"id":"`+testId+`"}`)),
+		WithRateLimiter(limiter),
+		WithCache(NewMemoryCache(10)),
+	)
+	defer server.Close()
+
+	start := time.Now()
+	for _, market := range []string{"ES", "US", "GB"} {
+		if _, err := spotify.GetTrack(testId, Market(market)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("Expected requests to be paced, took %v", elapsed)
+	}
+
+	start = time.Now()
+	if _, err := spotify.GetTrack(testId, Market("ES")); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 40*time.Millisecond {
+		t.Errorf("Expected cached response not to be paced, took %v", elapsed)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 calls, got %d", calls)
+	}
+}
+
+func TestRequestPriority(t *testing.T) {
+	player := &Request{Endpoint: "/me/player/pause"}
+	if p := requestPriority(context.Background(), player); p != PriorityHigh {
+		t.Errorf("Expected player command to be prioritized, got %d", p)
+	}
+	if p := requestPriority(WithPriority(context.Background(), PriorityLow), player); p != PriorityLow {
+		t.Errorf("Expected priority from the context, got %d", p)
+	}
+	if p := requestPriority(context.Background(), &Request{Endpoint: "/tracks"}); p != PriorityNormal {
+		t.Errorf("Expected normal priority, got %d", p)
+	}
+}
@@ -0,0 +1,180 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Loader collects the lookups of the single objects, such as tracks or albums, made within the short window,
+// and fetches them with the single request to the corresponding multi-id endpoint, like GetTracks does.
+// The repeated ids are fetched once, and every caller waiting for the id receives the same object,
+// so the objects returned by the Loader should not be modified.
+//
+// It is intended for the code resolving the objects one at a time, such as the GraphQL resolvers,
+// and can be used from many goroutines at once.
+type Loader struct {
+	tracks   *loader[FullTrack]
+	albums   *loader[FullAlbum]
+	artists  *loader[FullArtist]
+	episodes *loader[FullEpisode]
+	shows    *loader[FullShow]
+}
+
+// NewLoader creates the Loader, which waits for the given duration after the first lookup
+// before fetching the collected ones. The batch is fetched without waiting once it reaches the maximum
+// number of ids the endpoint accepts.
+// The params, such as Market, are added to every request of the endpoints supporting them.
+func NewLoader(s *Spotify, wait time.Duration, params ...Param) *Loader {
+	return &Loader{
+		tracks: newLoader(wait, maxTrackIds, "/tracks", func(ctx context.Context, ids []string) ([]*FullTrack, error) {
+			return s.GetTracksCtx(ctx, ids, params...)
+		}),
+		albums: newLoader(wait, maxAlbumIds, "/albums", func(ctx context.Context, ids []string) ([]*FullAlbum, error) {
+			return s.GetAlbumsCtx(ctx, ids, params...)
+		}),
+		artists: newLoader(wait, maxArtistIds, "/artists", s.GetArtistsCtx),
+		episodes: newLoader(wait, maxEpisodeIds, "/episodes", func(ctx context.Context, ids []string) ([]*FullEpisode, error) {
+			return s.GetEpisodesCtx(ctx, ids, params...)
+		}),
+		shows: newLoader(wait, maxShowIds, "/shows", func(ctx context.Context, ids []string) ([]*FullShow, error) {
+			return s.GetShowsCtx(ctx, ids, params...)
+		}),
+	}
+}
+
+// LoadTrack obtains the track with the given id, fetching it together with the other tracks looked up at the same time.
+// It fails with the error matching ErrNotFound if Spotify knows no track with such id.
+func (l *Loader) LoadTrack(ctx context.Context, id string) (*FullTrack, error) {
+	return l.tracks.load(ctx, id)
+}
+
+// LoadAlbum obtains the album with the given id, fetching it together with the other albums looked up at the same time.
+// It fails with the error matching ErrNotFound if Spotify knows no album with such id.
+func (l *Loader) LoadAlbum(ctx context.Context, id string) (*FullAlbum, error) {
+	return l.albums.load(ctx, id)
+}
+
+// LoadArtist obtains the artist with the given id, fetching it together with the other artists looked up at the same time.
+// It fails with the error matching ErrNotFound if Spotify knows no artist with such id.
+func (l *Loader) LoadArtist(ctx context.Context, id string) (*FullArtist, error) {
+	return l.artists.load(ctx, id)
+}
+
+// LoadEpisode obtains the episode with the given id, fetching it together with the other episodes looked up at the same time.
+// It fails with the error matching ErrNotFound if Spotify knows no episode with such id.
+func (l *Loader) LoadEpisode(ctx context.Context, id string) (*FullEpisode, error) {
+	return l.episodes.load(ctx, id)
+}
+
+// LoadShow obtains the show with the given id, fetching it together with the other shows looked up at the same time.
+// It fails with the error matching ErrNotFound if Spotify knows no show with such id.
+func (l *Loader) LoadShow(ctx context.Context, id string) (*FullShow, error) {
+	return l.shows.load(ctx, id)
+}
+
+// loader collects the lookups of the objects of type T into the batches.
+type loader[T any] struct {
+	mu sync.Mutex
+	// The batch collecting the lookups, or nil if there are none.
+	batch *loadBatch[T]
+	// The time the batch waits for the lookups after the first one.
+	wait time.Duration
+	// The maximum number of ids in the batch.
+	max int
+	// The endpoint of the objects, reported by the errors of the unknown ids.
+	endpoint string
+	// Fetches the objects with the given ids, returning them in the same order.
+	fetch func(ctx context.Context, ids []string) ([]*T, error)
+}
+
+// loadBatch is the set of the lookups fetched with the single request.
+type loadBatch[T any] struct {
+	// The context the batch is fetched with. It carries the values of the context of the first lookup, but not its cancellation.
+	ctx context.Context
+	// The unique ids of the batch, in the order they were looked up.
+	ids []string
+	// The lookups of the batch by their ids.
+	calls map[string]*loadCall[T]
+	// Fetches the batch once the wait is over.
+	timer *time.Timer
+}
+
+// loadCall is the lookup of the single id, shared by all the callers waiting for it.
+type loadCall[T any] struct {
+	// Closed once the object is fetched.
+	done chan struct{}
+	item *T
+	err  error
+}
+
+func newLoader[T any](
+	wait time.Duration,
+	max int,
+	endpoint string,
+	fetch func(ctx context.Context, ids []string) ([]*T, error),
+) *loader[T] {
+	return &loader[T]{wait: wait, max: max, endpoint: endpoint, fetch: fetch}
+}
+
+// load adds the id to the current batch, starting the new one if needed, and waits for the object to be fetched.
+func (l *loader[T]) load(ctx context.Context, id string) (*T, error) {
+	l.mu.Lock()
+	b := l.batch
+	if b == nil {
+		b = &loadBatch[T]{ctx: context.WithoutCancel(ctx), calls: map[string]*loadCall[T]{}}
+		b.timer = time.AfterFunc(l.wait, func() { l.dispatch(b) })
+		l.batch = b
+	}
+	call, ok := b.calls[id]
+	if !ok {
+		call = &loadCall[T]{done: make(chan struct{})}
+		b.calls[id] = call
+		b.ids = append(b.ids, id)
+	}
+	full := len(b.ids) >= l.max
+	l.mu.Unlock()
+
+	if full {
+		go l.dispatch(b)
+	}
+
+	select {
+	case <-call.done:
+		return call.item, call.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// dispatch fetches the batch, unless it was already fetched, and hands the objects to the callers waiting for them.
+func (l *loader[T]) dispatch(b *loadBatch[T]) {
+	l.mu.Lock()
+	if l.batch != b {
+		l.mu.Unlock()
+		return
+	}
+	l.batch = nil
+	l.mu.Unlock()
+	b.timer.Stop()
+
+	items, err := l.fetch(b.ctx, b.ids)
+	for i, id := range b.ids {
+		call := b.calls[id]
+		switch {
+		case err != nil:
+			call.err = err
+		case i >= len(items) || items[i] == nil:
+			call.err = &Error{
+				Status:   http.StatusNotFound,
+				Message:  "Not found: " + id,
+				Method:   http.MethodGet,
+				Endpoint: l.endpoint,
+			}
+		default:
+			call.item = items[i]
+		}
+		close(call.done)
+	}
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLoader(t *testing.T) {
+	var mu sync.Mutex
+	var batches [][]string
+	server, spotify := testServer(testBatchHandler(&mu, &batches))
+	defer server.Close()
+
+	loader := NewLoader(spotify, 20*time.Millisecond)
+	ids := []string{"track-0", "track-1", "track-0", "unknown-2", "track-3", "track-1"}
+	tracks := make([]*FullTrack, len(ids))
+	errs := make([]error, len(ids))
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			tracks[i], errs[i] = loader.LoadTrack(context.Background(), id)
+		}(i, id)
+	}
+	wg.Wait()
+
+	if len(batches) != 1 || len(batches[0]) != 4 {
+		t.Fatalf("Expected the single batch of 4 unique ids, got %v", batches)
+	}
+	for i, id := range ids {
+		if id == "unknown-2" {
+			if !errors.Is(errs[i], ErrNotFound) {
+				t.Errorf("Expected %v, got %v", ErrNotFound, errs[i])
+			}
+			continue
+		}
+		if errs[i] != nil {
+			t.Fatal(errs[i])
+		}
+		if tracks[i].Id != id {
+			t.Errorf("Expected %s, got %s", id, tracks[i].Id)
+		}
+	}
+}
+
+func TestLoaderFullBatch(t *testing.T) {
+	var mu sync.Mutex
+	var batches [][]string
+	server, spotify := testServer(testBatchHandler(&mu, &batches))
+	defer server.Close()
+
+	loader := NewLoader(spotify, time.Hour)
+	ids := testBatchIds(maxTrackIds)
+	var wg sync.WaitGroup
+	for _, id := range ids {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			if _, err := loader.LoadTrack(context.Background(), id); err != nil {
+				t.Error(err)
+			}
+		}(id)
+	}
+	wg.Wait()
+
+	if len(batches) != 1 || len(batches[0]) != maxTrackIds {
+		t.Errorf("Expected the full batch to be fetched without waiting, got %d batches", len(batches))
+	}
+}
+
+func TestLoaderError(t *testing.T) {
+	server, spotify := testServer(testErrorHandler(http.StatusUnauthorized, nil, ""))
+	defer server.Close()
+
+	loader := NewLoader(spotify, time.Millisecond)
+	_, err := loader.LoadAlbum(context.Background(), testId)
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Expected %v, got %v", ErrUnauthorized, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = NewLoader(spotify, time.Hour).LoadArtist(ctx, testId)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected %v, got %v", context.Canceled, err)
+	}
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/Alieksieiev0/sgotify/internal/redact"
+)
+
+// LogOptions describes how the Spotify client logs the requests it sends.
+type LogOptions struct {
+	// The level of the requests that succeeded.
+	Level slog.Level
+	// The level of the requests that failed, either with the error or with the status outside the 2xx class.
+	ErrorLevel slog.Level
+	// The level of the messages about the retried requests.
+	RetryLevel slog.Level
+	// Whether the bodies of the requests and responses are logged as well.
+	// They are logged at the debug level, with the credentials redacted.
+	Bodies bool
+}
+
+// DefaultLogOptions returns the options used by the clients created with WithLogger, unless WithLogOptions is supplied.
+// Successful requests are logged at the info level, failed and retried ones at the warn level.
+func DefaultLogOptions() LogOptions {
+	return LogOptions{
+		Level:      slog.LevelInfo,
+		ErrorLevel: slog.LevelWarn,
+		RetryLevel: slog.LevelWarn,
+	}
+}
+
+// loggingMiddleware logs every request sent to Spotify, together with its status, latency and attempt.
+// It runs inside the cache and the rate limiter, just outside the metrics,
+// so it logs only the requests that actually leave the client.
+// The 304 responses to the revalidated cache entries are logged as successful, as the cache serves them.
+func (s *Spotify) loggingMiddleware(next Handler) Handler {
+	return func(ctx context.Context, req *Request) (*Response, error) {
+		start := time.Now()
+		res, err := next(ctx, req)
+		latency := time.Since(start)
+
+		attrs := []slog.Attr{
+			slog.String("method", req.Method),
+			slog.String("endpoint", req.Endpoint),
+			slog.String("params", redact.Query(req.Params).Encode()),
+			slog.Int("attempt", req.Attempt),
+			slog.Duration("latency", latency),
+		}
+		level := s.logOptions.Level
+		switch {
+		case err != nil:
+			level = s.logOptions.ErrorLevel
+			attrs = append(attrs, slog.String("error", err.Error()))
+		case res != nil:
+			if !isSuccess(res.StatusCode) && res.StatusCode != http.StatusNotModified {
+				level = s.logOptions.ErrorLevel
+			}
+			attrs = append(attrs, slog.Int("status", res.StatusCode))
+		}
+		s.logger.LogAttrs(ctx, level, "spotify request", attrs...)
+
+		if !s.logOptions.Bodies || !s.logger.Enabled(ctx, slog.LevelDebug) {
+			return res, err
+		}
+		bodyAttrs := []slog.Attr{
+			slog.String("method", req.Method),
+			slog.String("endpoint", req.Endpoint),
+			slog.Any("header", redact.Header(req.Header)),
+			slog.String("request_body", redact.Body(req.Body)),
+		}
+		if res != nil {
+			body, readErr := res.readBody()
+			if readErr != nil {
+				return nil, readErr
+			}
+			res.Body = io.NopCloser(bytes.NewReader(body))
+			bodyAttrs = append(bodyAttrs, slog.String("response_body", redact.Body(body)))
+		}
+		s.logger.LogAttrs(ctx, slog.LevelDebug, "spotify request body", bodyAttrs...)
+		return res, err
+	}
+}
+
+// logRetry logs that the failed request is going to be retried after the delay.
+func (s *Spotify) logRetry(ctx context.Context, req *Request, delay time.Duration, err error) {
+	if s.logger == nil {
+		return
+	}
+	s.logger.LogAttrs(ctx, s.logOptions.RetryLevel, "spotify request retry",
+		slog.String("method", req.Method),
+		slog.String("endpoint", req.Endpoint),
+		slog.Int("attempt", req.Attempt+1),
+		slog.Duration("delay", delay),
+		slog.String("error", err.Error()),
+	)
+}
@@ -0,0 +1,141 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func testLogger(buf *bytes.Buffer) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+}
+
+func testLogRecords(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		record := map[string]any{}
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	var calls int32
+	server, spotify := testServer(
+		testFlakyHandler(&calls, 1, http.StatusServiceUnavailable, []byte(`{"id":"`+testId+`"}`)),
+		WithRetryPolicy(testRetryPolicy()),
+		WithLogger(testLogger(&buf)),
+	)
+	defer server.Close()
+
+	_, err := spotify.GetTrack(testId, Market("ES"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	records := testLogRecords(t, &buf)
+	expected := []struct {
+		msg    string
+		level  string
+		status float64
+	}{
+		{"spotify request", "WARN", http.StatusServiceUnavailable},
+		{"spotify request retry", "WARN", 0},
+		{"spotify request", "INFO", http.StatusOK},
+	}
+	if len(records) != len(expected) {
+		t.Fatalf("Expected %d records, got %d: %v", len(expected), len(records), records)
+	}
+	for i, e := range expected {
+		record := records[i]
+		if record["msg"] != e.msg || record["level"] != e.level {
+			t.Errorf("Expected %s at %s, got %v", e.msg, e.level, record)
+		}
+		if record["endpoint"] != "/tracks/"+testId || record["method"] != http.MethodGet {
+			t.Errorf("Unexpected request in %v", record)
+		}
+		if e.status != 0 && record["status"] != e.status {
+			t.Errorf("Expected status %v, got %v", e.status, record["status"])
+		}
+	}
+	if records[0]["params"] != "market=ES" || records[2]["attempt"] != float64(1) {
+		t.Errorf("Unexpected params or attempt in %v", records)
+	}
+	if _, ok := records[0]["latency"]; !ok {
+		t.Errorf("Expected latency in %v", records[0])
+	}
+}
+
+func TestLoggerRevalidation(t *testing.T) {
+	var buf bytes.Buffer
+	calls := 0
+	header := http.Header{"Cache-Control": []string{"max-age=0"}, "Etag": []string{`"v1"`}}
+	server, spotify := testServer(
+		testCacheHandler(&calls, header, []byte(`{"id":"`+testId+`"}`)),
+		WithCache(NewMemoryCache(10)),
+		WithLogger(testLogger(&buf)),
+	)
+	defer server.Close()
+
+	for i := 0; i < 2; i++ {
+		if _, err := spotify.GetTrack(testId); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	records := testLogRecords(t, &buf)
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d: %v", len(records), records)
+	}
+	if records[1]["status"] != float64(http.StatusNotModified) || records[1]["level"] != "INFO" {
+		t.Errorf("Expected revalidation to be logged at INFO, got %v", records[1])
+	}
+}
+
+func TestLoggerBodies(t *testing.T) {
+	var buf bytes.Buffer
+	options := DefaultLogOptions()
+	options.Bodies = true
+	server, spotify := testServer(
+		testBodyOnlyHandler([]byte(`{"access_token":"secret","id":"`+testId+`"}`)),
+		WithLogger(testLogger(&buf)),
+		WithLogOptions(options),
+		WithMiddleware(func(next Handler) Handler {
+			return func(ctx context.Context, req *Request) (*Response, error) {
+				req.Header.Set("Authorization", "Bearer secret")
+				req.Params.Set("code", "secret")
+				return next(ctx, req)
+			}
+		}),
+	)
+	defer server.Close()
+
+	track, err := spotify.GetTrack(testId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if track.Id != testId {
+		t.Errorf("Expected logged body to be decoded, got %s", track.Id)
+	}
+
+	out := buf.String()
+	if strings.Contains(out, "secret") {
+		t.Errorf("Expected credentials to be redacted, got %s", out)
+	}
+	records := testLogRecords(t, &buf)
+	if len(records) != 2 || records[1]["msg"] != "spotify request body" {
+		t.Fatalf("Expected request and body records, got %v", records)
+	}
+	if records[1]["response_body"] != `{"access_token":"REDACTED","id":"`+testId+`"}` {
+		t.Errorf("Unexpected response body: %v", records[1]["response_body"])
+	}
+}
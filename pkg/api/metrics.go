@@ -0,0 +1,239 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Metrics receives the measurements of the requests made by the Spotify client.
+// Implementations must be safe for concurrent use.
+// MetricsRegistry is the built-in implementation, which can be exposed to Prometheus.
+type Metrics interface {
+	// ObserveRequest is called once the request sent to Spotify finishes.
+	// The endpoint has the ids replaced with placeholders, such as /albums/{id}/tracks.
+	// The status is 0 if no response was received.
+	ObserveRequest(method, endpoint string, status int, latency time.Duration)
+	// ObserveRetry is called every time the failed request is going to be retried.
+	ObserveRetry(method, endpoint string)
+	// ObserveRateLimitWait is called every time the request waited for the rate limiter.
+	ObserveRateLimitWait(wait time.Duration)
+}
+
+// The segments of the endpoints, which are followed by the id.
+var idCollections = map[string]bool{
+	"albums":         true,
+	"artists":        true,
+	"audiobooks":     true,
+	"chapters":       true,
+	"episodes":       true,
+	"shows":          true,
+	"tracks":         true,
+	"playlists":      true,
+	"users":          true,
+	"categories":     true,
+	"audio-features": true,
+	"audio-analysis": true,
+}
+
+// The segments, which follow the collection in place of the id, such as in /me/tracks/contains.
+var actionSegments = map[string]bool{
+	"contains": true,
+}
+
+// endpointPattern replaces the ids in the endpoint with the {id} placeholder,
+// so the metrics of the same endpoint are not split between the requested resources.
+func endpointPattern(endpoint string) string {
+	segments := strings.Split(endpoint, "/")
+	for i := 1; i < len(segments); i++ {
+		if idCollections[segments[i-1]] && segments[i] != "" && !actionSegments[segments[i]] {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// metricsMiddleware reports every request sent to Spotify to the Metrics of the client.
+func (s *Spotify) metricsMiddleware(next Handler) Handler {
+	return func(ctx context.Context, req *Request) (*Response, error) {
+		start := time.Now()
+		res, err := next(ctx, req)
+
+		status := 0
+		if err == nil && res != nil {
+			status = res.StatusCode
+		}
+		s.metrics.ObserveRequest(req.Method, endpointPattern(req.Endpoint), status, time.Since(start))
+		return res, err
+	}
+}
+
+// The upper bounds of the latency histogram buckets, in seconds.
+var latencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// MetricsRegistry is the in-process Metrics, which serves the collected values in the Prometheus text format.
+// It exposes the following metrics:
+//   - spotify_requests_total, the counter of the requests by method, endpoint and status;
+//   - spotify_request_duration_seconds, the histogram of the request latency by method and endpoint;
+//   - spotify_retries_total, the counter of the retries by method and endpoint;
+//   - spotify_rate_limit_wait_seconds, the histogram of the time spent waiting for the rate limiter.
+//
+// It is recommended to create MetricsRegistry through the NewMetricsRegistry function.
+type MetricsRegistry struct {
+	mu        sync.Mutex
+	requests  map[[3]string]uint64
+	latencies map[[2]string]*histogram
+	retries   map[[2]string]uint64
+	waits     *histogram
+}
+
+// NewMetricsRegistry creates the empty MetricsRegistry.
+func NewMetricsRegistry() *MetricsRegistry {
+	return &MetricsRegistry{
+		requests:  map[[3]string]uint64{},
+		latencies: map[[2]string]*histogram{},
+		retries:   map[[2]string]uint64{},
+		waits:     newHistogram(),
+	}
+}
+
+// ObserveRequest counts the request and records its latency.
+func (r *MetricsRegistry) ObserveRequest(method, endpoint string, status int, latency time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	statusLabel := "error"
+	if status != 0 {
+		statusLabel = strconv.Itoa(status)
+	}
+	r.requests[[3]string{method, endpoint, statusLabel}]++
+
+	key := [2]string{method, endpoint}
+	h, ok := r.latencies[key]
+	if !ok {
+		h = newHistogram()
+		r.latencies[key] = h
+	}
+	h.observe(latency.Seconds())
+}
+
+// ObserveRetry counts the retry.
+func (r *MetricsRegistry) ObserveRetry(method, endpoint string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.retries[[2]string{method, endpoint}]++
+}
+
+// ObserveRateLimitWait records the time spent waiting for the rate limiter.
+func (r *MetricsRegistry) ObserveRateLimitWait(wait time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.waits.observe(wait.Seconds())
+}
+
+// ServeHTTP writes the collected metrics in the Prometheus text format,
+// so the registry can be mounted as the scrape endpoint, such as /metrics.
+func (r *MetricsRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	r.WriteTo(w)
+}
+
+// WriteTo writes the collected metrics in the Prometheus text format to the writer.
+func (r *MetricsRegistry) WriteTo(w io.Writer) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var b strings.Builder
+	b.WriteString("# HELP spotify_requests_total Requests sent to the Spotify API.\n")
+	b.WriteString("# TYPE spotify_requests_total counter\n")
+	for _, key := range sortedKeys(r.requests) {
+		labels := formatLabels("method", key[0], "endpoint", key[1], "status", key[2])
+		fmt.Fprintf(&b, "spotify_requests_total%s %d\n", labels, r.requests[key])
+	}
+
+	b.WriteString("# HELP spotify_request_duration_seconds Latency of the requests sent to the Spotify API.\n")
+	b.WriteString("# TYPE spotify_request_duration_seconds histogram\n")
+	for _, key := range sortedKeys(r.latencies) {
+		r.latencies[key].write(&b, "spotify_request_duration_seconds", "method", key[0], "endpoint", key[1])
+	}
+
+	b.WriteString("# HELP spotify_retries_total Retries of the failed requests to the Spotify API.\n")
+	b.WriteString("# TYPE spotify_retries_total counter\n")
+	for _, key := range sortedKeys(r.retries) {
+		labels := formatLabels("method", key[0], "endpoint", key[1])
+		fmt.Fprintf(&b, "spotify_retries_total%s %d\n", labels, r.retries[key])
+	}
+
+	b.WriteString("# HELP spotify_rate_limit_wait_seconds Time the requests waited for the rate limiter.\n")
+	b.WriteString("# TYPE spotify_rate_limit_wait_seconds histogram\n")
+	r.waits.write(&b, "spotify_rate_limit_wait_seconds")
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// histogram counts the observed values in the latencyBuckets.
+type histogram struct {
+	// The number of values in each bucket, not including the smaller buckets.
+	// The last one counts the values above the largest bound.
+	buckets []uint64
+	sum     float64
+	count   uint64
+}
+
+func newHistogram() *histogram {
+	return &histogram{buckets: make([]uint64, len(latencyBuckets)+1)}
+}
+
+// observe adds the value to the histogram.
+func (h *histogram) observe(value float64) {
+	i := sort.SearchFloat64s(latencyBuckets, value)
+	h.buckets[i]++
+	h.sum += value
+	h.count++
+}
+
+// write writes the cumulative buckets, the sum and the count of the histogram with the given labels.
+func (h *histogram) write(b *strings.Builder, name string, labels ...string) {
+	var cumulative uint64
+	for i, bound := range latencyBuckets {
+		cumulative += h.buckets[i]
+		le := strconv.FormatFloat(bound, 'g', -1, 64)
+		fmt.Fprintf(b, "%s_bucket%s %d\n", name, formatLabels(append(labels, "le", le)...), cumulative)
+	}
+	fmt.Fprintf(b, "%s_bucket%s %d\n", name, formatLabels(append(labels, "le", "+Inf")...), h.count)
+	fmt.Fprintf(b, "%s_sum%s %s\n", name, formatLabels(labels...), strconv.FormatFloat(h.sum, 'g', -1, 64))
+	fmt.Fprintf(b, "%s_count%s %d\n", name, formatLabels(labels...), h.count)
+}
+
+// formatLabels formats the pairs of label names and values, escaping the values.
+func formatLabels(pairs ...string) string {
+	if len(pairs) == 0 {
+		return ""
+	}
+
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	labels := make([]string, 0, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		labels = append(labels, pairs[i]+`="`+escaper.Replace(pairs[i+1])+`"`)
+	}
+	return "{" + strings.Join(labels, ",") + "}"
+}
+
+// sortedKeys returns the keys of the map in order, so the output of the registry is stable.
+func sortedKeys[K [2]string | [3]string, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+	})
+	return keys
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEndpointPattern(t *testing.T) {
+	tests := map[string]string{
+		"/albums/" + testId + "/tracks":                "/albums/{id}/tracks",
+		"/users/smedjan/playlists":                     "/users/{id}/playlists",
+		"/browse/categories/dinner/playlists":          "/browse/categories/{id}/playlists",
+		"/me/tracks":                                   "/me/tracks",
+		"/me/player/volume":                            "/me/player/volume",
+		"/playlists/" + testId + "/tracks":             "/playlists/{id}/tracks",
+		"/recommendations/available-genre-seeds":       "/recommendations/available-genre-seeds",
+		"/me/tracks/contains":                          "/me/tracks/contains",
+		"/me/albums/contains":                          "/me/albums/contains",
+		"/me/shows/contains":                           "/me/shows/contains",
+		"/me/episodes/contains":                        "/me/episodes/contains",
+		"/me/audiobooks/contains":                      "/me/audiobooks/contains",
+		"/playlists/" + testId + "/followers/contains": "/playlists/{id}/followers/contains",
+	}
+	for endpoint, expected := range tests {
+		if pattern := endpointPattern(endpoint); pattern != expected {
+			t.Errorf("Expected %s for %s, got %s", expected, endpoint, pattern)
+		}
+	}
+}
+
+func TestMetricsRegistry(t *testing.T) {
+	registry := NewMetricsRegistry()
+	var calls int32
+	server, spotify := testServer(
+		testFlakyHandler(&calls, 1, http.StatusServiceUnavailable, []byte(`{"id":"`+testId+`"}`)),
+		WithRetryPolicy(testRetryPolicy()),
+		WithRateLimiter(NewRateLimiter(1000, 10)),
+		WithMetrics(registry),
+	)
+	defer server.Close()
+
+	if _, err := spotify.GetTrack(testId); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	registry.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	out := rec.Body.String()
+	for _, line := range []string{
+		"# TYPE spotify_requests_total counter",
+		`spotify_requests_total{method="GET",endpoint="/tracks/{id}",status="200"} 1`,
+		`spotify_requests_total{method="GET",endpoint="/tracks/{id}",status="503"} 1`,
+		`spotify_request_duration_seconds_bucket{method="GET",endpoint="/tracks/{id}",le="+Inf"} 2`,
+		`spotify_request_duration_seconds_count{method="GET",endpoint="/tracks/{id}"} 2`,
+		`spotify_retries_total{method="GET",endpoint="/tracks/{id}"} 1`,
+		`spotify_rate_limit_wait_seconds_count 2`,
+	} {
+		if !strings.Contains(out, line+"\n") {
+			t.Errorf("Expected %q in the output:\n%s", line, out)
+		}
+	}
+	if !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/plain") {
+		t.Errorf("Unexpected content type: %s", rec.Header().Get("Content-Type"))
+	}
+}
+
+func TestHistogramBuckets(t *testing.T) {
+	registry := NewMetricsRegistry()
+	registry.ObserveRequest(http.MethodGet, "/me", 0, 30*time.Millisecond)
+	registry.ObserveRequest(http.MethodGet, "/me", 0, 20*time.Second)
+
+	var b strings.Builder
+	if _, err := registry.WriteTo(&b); err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+	for _, line := range []string{
+		`spotify_requests_total{method="GET",endpoint="/me",status="error"} 2`,
+		`spotify_request_duration_seconds_bucket{method="GET",endpoint="/me",le="0.025"} 0`,
+		`spotify_request_duration_seconds_bucket{method="GET",endpoint="/me",le="0.05"} 1`,
+		`spotify_request_duration_seconds_bucket{method="GET",endpoint="/me",le="10"} 1`,
+		`spotify_request_duration_seconds_bucket{method="GET",endpoint="/me",le="+Inf"} 2`,
+		`spotify_request_duration_seconds_sum{method="GET",endpoint="/me"} 20.03`,
+	} {
+		if !strings.Contains(out, line+"\n") {
+			t.Errorf("Expected %q in the output:\n%s", line, out)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// Request describes a single attempt of the request made by the Spotify client, as seen by the middleware.
+// Middleware can modify it before passing it to the next Handler.
+type Request struct {
+	// The method of the request.
+	// The methods used are GET, PUT, POST, DELETE.
+	Method string
+	// The endpoint of the request, relative to the base url of the Spotify and without the query.
+	// It is escaped the same way as it is sent, for example "/users/we%3Fird".
+	Endpoint string
+	// The params of the request, including the ones supplied with the endpoint.
+	Params url.Values
+	// The headers of the request.
+	Header http.Header
+	// The body of the request.
+	Body []byte
+	// The number of the attempt, starting from zero. It is increased every time the request is retried.
+	Attempt int
+}
+
+// Response describes the response received by the Spotify client, as seen by the middleware.
+// Responses with the status outside the 2xx class are turned into the *Error after leaving the middleware,
+// so they are retried according to the retry policy of the client, even if they are synthetic.
+type Response struct {
+	// The HTTP status code of the response.
+	StatusCode int
+	// The headers of the response.
+	Header http.Header
+	// The body of the response. It is closed by the Spotify client once the response is read.
+	// Nil body is treated as empty.
+	Body io.ReadCloser
+}
+
+// NewResponse creates the Response with the given status and body.
+// It is intended for the middleware, which answers requests without sending them.
+func NewResponse(status int, body []byte) *Response {
+	return &Response{
+		StatusCode: status,
+		Header:     http.Header{},
+		Body:       io.NopCloser(bytes.NewReader(body)),
+	}
+}
+
+// readBody reads and closes the body of the response.
+func (r *Response) readBody() ([]byte, error) {
+	if r.Body == nil {
+		return nil, nil
+	}
+	defer r.Body.Close()
+	return io.ReadAll(r.Body)
+}
+
+// Handler handles the Request, returning its Response.
+// The error is returned only if no response could be obtained.
+type Handler func(ctx context.Context, req *Request) (*Response, error)
+
+// Middleware wraps the next Handler, adding behaviour to every request made by the Spotify client,
+// such as logging, adding headers, or answering requests with the synthetic Response.
+// Middleware that does not call the next Handler short-circuits the request, so it is never sent.
+type Middleware func(next Handler) Handler
+
+// handler chains the middleware of the client around the sendRequest.
+// The first middleware is the outermost one: it sees the request first and the response last.
+// The built-in middleware, such as the cache and the rate limiter, is placed after the one supplied by the user.
+func (s *Spotify) handler() Handler {
+	handler := s.sendRequest
+	if s.metrics != nil {
+		handler = s.metricsMiddleware(handler)
+	}
+	if s.logger != nil {
+		handler = s.loggingMiddleware(handler)
+	}
+	if s.limiter != nil {
+		handler = s.limiterMiddleware(handler)
+	}
+	if s.cache != nil {
+		handler = s.cacheMiddleware(handler)
+	}
+	if s.flights != nil {
+		handler = s.singleflightMiddleware(handler)
+	}
+	if s.dryRun != nil {
+		handler = s.dryRunMiddleware(handler)
+	}
+	for i := len(s.middleware) - 1; i >= 0; i-- {
+		handler = s.middleware[i](handler)
+	}
+	return handler
+}
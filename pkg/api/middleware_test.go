@@ -0,0 +1,139 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMiddlewareOrder(t *testing.T) {
+	var order []string
+	record := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, req *Request) (*Response, error) {
+				order = append(order, name+" before")
+				res, err := next(ctx, req)
+				order = append(order, name+" after")
+				return res, err
+			}
+		}
+	}
+
+	server, spotify := testServer(
+		testHandler(),
+		WithMiddleware(record("first"), record("second")),
+		WithMiddleware(record("third")),
+	)
+	defer server.Close()
+
+	err := spotify.PausePlayback()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"first before",
+		"second before",
+		"third before",
+		"third after",
+		"second after",
+		"first after",
+	}
+	if !reflect.DeepEqual(order, expected) {
+		t.Errorf("Expected %v, got %v", expected, order)
+	}
+}
+
+func TestMiddlewareSeesRequest(t *testing.T) {
+	var header string
+	var seen Request
+	var status int
+	server, spotify := testServer(
+		func(w http.ResponseWriter, r *http.Request) {
+			header = r.Header.Get("X-Test")
+		},
+		WithMiddleware(func(next Handler) Handler {
+			return func(ctx context.Context, req *Request) (*Response, error) {
+				req.Header.Set("X-Test", "injected")
+				seen = *req
+				res, err := next(ctx, req)
+				if res != nil {
+					status = res.StatusCode
+				}
+				return res, err
+			}
+		}),
+	)
+	defer server.Close()
+
+	err := spotify.SetPlaybackVolume(50, DeviceId("device"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if seen.Method != http.MethodPut || seen.Endpoint != "/me/player/volume" {
+		t.Errorf("Unexpected request: %s %s", seen.Method, seen.Endpoint)
+	}
+	if seen.Params.Get("volume_percent") != "50" || seen.Params.Get("device_id") != "device" {
+		t.Errorf("Unexpected params: %v", seen.Params)
+	}
+	if header != "injected" {
+		t.Errorf("Expected injected header, got %q", header)
+	}
+	if status != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, status)
+	}
+}
+
+func TestMiddlewareShortCircuit(t *testing.T) {
+	server, spotify := testServer(
+		func(w http.ResponseWriter, r *http.Request) {
+			panic("request should not be sent")
+		},
+		WithMiddleware(func(next Handler) Handler {
+			return func(ctx context.Context, req *Request) (*Response, error) {
+				return NewResponse(http.StatusOK, []byte(`{"id":"`+testId+`"}`)), nil
+			}
+		}),
+	)
+	defer server.Close()
+
+	track, err := spotify.GetTrack(testId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if track.Id != testId {
+		t.Errorf("Expected %s, got %s", testId, track.Id)
+	}
+}
+
+func TestMiddlewareFaultInjection(t *testing.T) {
+	var attempts []int
+	server, spotify := testServer(
+		testBodyOnlyHandler([]byte(`{"id":"`+testId+`"}`)),
+		WithRetryPolicy(RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond}),
+		WithMiddleware(func(next Handler) Handler {
+			return func(ctx context.Context, req *Request) (*Response, error) {
+				attempts = append(attempts, req.Attempt)
+				if req.Attempt == 0 {
+					return NewResponse(http.StatusServiceUnavailable, nil), nil
+				}
+				return next(ctx, req)
+			}
+		}),
+	)
+	defer server.Close()
+
+	track, err := spotify.GetTrack(testId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if track.Id != testId {
+		t.Errorf("Expected %s, got %s", testId, track.Id)
+	}
+	if !reflect.DeepEqual(attempts, []int{0, 1}) {
+		t.Errorf("Expected attempts [0 1], got %v", attempts)
+	}
+}
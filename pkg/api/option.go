@@ -0,0 +1,204 @@
+package api
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+// The base url of the Spotify Web API, used unless WithBaseURL is supplied.
+const defaultBaseURL = "https://api.spotify.com/v1"
+
+// clientConfig collects the settings supplied to the NewSpotifyClient.
+type clientConfig struct {
+	// The client being built. Options that do not affect the HTTP client set its fields directly.
+	spotify *Spotify
+	// The HTTP client used as the base of the Spotify client.
+	httpClient *http.Client
+	// The transport used instead of the one of the HTTP client.
+	transport http.RoundTripper
+	// The source of the tokens added to every request.
+	tokenSource oauth2.TokenSource
+	// The value of the User-Agent header added to every request.
+	userAgent string
+}
+
+// Option is used to conveniently add additional settings to the Spotify client.
+type Option func(c *clientConfig)
+
+// WithBaseURL sets the base url of the Spotify API, which is prepended to every endpoint.
+// It is mostly useful to point the client to a test server.
+func WithBaseURL(url string) Option {
+	return func(c *clientConfig) {
+		c.spotify.url = strings.TrimSuffix(url, "/")
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to send requests.
+// If the token source is supplied as well, the client's transport is wrapped to authorize requests,
+// otherwise the client is expected to authorize them on its own.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *clientConfig) {
+		c.httpClient = client
+	}
+}
+
+// WithTransport sets the transport used to send requests, in place of the one of the HTTP client.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *clientConfig) {
+		c.transport = transport
+	}
+}
+
+// WithTokenSource sets the source of the tokens used to authorize requests.
+// Using the source that can refresh the token, such as the one returned by the oauth2.Config,
+// keeps the client working after the access token expires.
+func WithTokenSource(source oauth2.TokenSource) Option {
+	return func(c *clientConfig) {
+		c.tokenSource = source
+	}
+}
+
+// WithToken sets the static token used to authorize requests.
+// The token is not refreshed, so the client stops working when it expires.
+func WithToken(token *oauth2.Token) Option {
+	return WithTokenSource(oauth2.StaticTokenSource(token))
+}
+
+// WithUserAgent sets the value of the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *clientConfig) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithRetryPolicy sets the policy used to retry failed requests, in place of the DefaultRetryPolicy.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *clientConfig) {
+		c.spotify.retry = &policy
+	}
+}
+
+// WithMiddleware adds the middleware to the chain every request passes through.
+// Middleware is called in the order it was added, so the first one is the outermost.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(c *clientConfig) {
+		c.spotify.middleware = append(c.spotify.middleware, middleware...)
+	}
+}
+
+// WithCache sets the cache of the GET responses, such as the MemoryCache or the DiskCache.
+// Responses are cached according to their Cache-Control and ETag headers.
+func WithCache(cache Cache) Option {
+	return func(c *clientConfig) {
+		c.spotify.cache = cache
+	}
+}
+
+// WithRateLimiter sets the limiter pacing the requests sent to Spotify.
+// The limiter can be shared between the clients using the credentials of the same app,
+// so they stay under the common rate limit together.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(c *clientConfig) {
+		c.spotify.limiter = limiter
+	}
+}
+
+// WithSingleflight makes the client coalesce the identical GET requests made at the same time,
+// such as many goroutines asking for the same album, into the single request.
+// Every caller still receives its own copy of the decoded response.
+func WithSingleflight() Option {
+	return func(c *clientConfig) {
+		c.spotify.flights = &flightGroup{calls: map[string]*flightCall{}}
+	}
+}
+
+// WithLogger sets the logger of the requests sent to Spotify.
+// Every request is logged with its method, endpoint, params, status, latency and attempt,
+// while the credentials, such as the tokens and the authorization codes, are redacted.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *clientConfig) {
+		c.spotify.logger = logger
+	}
+}
+
+// WithLogOptions sets the options describing how the requests are logged, in place of the DefaultLogOptions.
+// It has no effect unless the logger is supplied with WithLogger.
+func WithLogOptions(options LogOptions) Option {
+	return func(c *clientConfig) {
+		c.spotify.logOptions = options
+	}
+}
+
+// WithMetrics sets the metrics, which receive the measurements of every request sent to Spotify,
+// such as the MetricsRegistry.
+func WithMetrics(metrics Metrics) Option {
+	return func(c *clientConfig) {
+		c.spotify.metrics = metrics
+	}
+}
+
+// WithDryRun enables the dry-run mode, in which the PUT, POST and DELETE requests are not sent,
+// but recorded in the given log. GET requests are sent as usual.
+// Mutating requests, which return the *Snapshot, return the one with the DryRunSnapshotId.
+func WithDryRun(log *DryRunLog) Option {
+	return func(c *clientConfig) {
+		c.spotify.dryRun = log
+	}
+}
+
+// WithBatchConcurrency sets the number of the batches sent at the same time by the methods,
+// which split their ids into several requests, such as GetTracks called with more than 50 ids.
+func WithBatchConcurrency(n int) Option {
+	return func(c *clientConfig) {
+		c.spotify.batchConcurrency = n
+	}
+}
+
+// buildHTTPClient creates the HTTP client, which sends requests through the configured transport,
+// adding the User-Agent header and the token to them.
+// If no HTTP client was supplied, the one stored in the context under oauth2.HTTPClient is used as the base.
+func (c *clientConfig) buildHTTPClient(ctx context.Context) *http.Client {
+	client := &http.Client{}
+	if c.httpClient != nil {
+		*client = *c.httpClient
+	} else if ctxClient, ok := ctx.Value(oauth2.HTTPClient).(*http.Client); ok {
+		*client = *ctxClient
+	}
+
+	transport := client.Transport
+	if c.transport != nil {
+		transport = c.transport
+	}
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	if c.userAgent != "" {
+		transport = &userAgentTransport{c.userAgent, transport}
+	}
+	if c.spotify.tokenSource != nil {
+		transport = &oauth2.Transport{
+			Source: c.spotify.tokenSource,
+			Base:   transport,
+		}
+	}
+
+	client.Transport = transport
+	return client
+}
+
+// userAgentTransport sets the User-Agent header of every request sent through it.
+type userAgentTransport struct {
+	userAgent string
+	base      http.RoundTripper
+}
+
+// RoundTrip sends the copy of the request with the User-Agent header set.
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.userAgent)
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+type testRoundTripper struct {
+	requests []*http.Request
+	base     http.RoundTripper
+}
+
+func (t *testRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests = append(t.requests, req)
+	return t.base.RoundTrip(req)
+}
+
+func TestNewSpotifyClientDefaults(t *testing.T) {
+	spotify := NewSpotifyClient(context.Background())
+	if spotify.url != defaultBaseURL {
+		t.Errorf("Expected %s, got %s", defaultBaseURL, spotify.url)
+	}
+	if spotify.retry == nil || *spotify.retry != DefaultRetryPolicy() {
+		t.Errorf("Expected default retry policy, got %v", spotify.retry)
+	}
+}
+
+func TestNewSpotifyClientOptions(t *testing.T) {
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+	}))
+	defer server.Close()
+
+	transport := &testRoundTripper{base: http.DefaultTransport}
+	spotify := NewSpotifyClient(
+		context.Background(),
+		WithBaseURL(server.URL+"/"),
+		WithHTTPClient(&http.Client{}),
+		WithTransport(transport),
+		WithToken(&oauth2.Token{AccessToken: "token", TokenType: "Bearer"}),
+		WithUserAgent("sgotify-test"),
+	)
+
+	err := spotify.PausePlayback()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(transport.requests) != 1 {
+		t.Fatalf("Expected 1 request through the transport, got %d", len(transport.requests))
+	}
+	if auth := header.Get("Authorization"); auth != "Bearer token" {
+		t.Errorf("Expected %s, got %s", "Bearer token", auth)
+	}
+	if ua := header.Get("User-Agent"); ua != "sgotify-test" {
+		t.Errorf("Expected %s, got %s", "sgotify-test", ua)
+	}
+}
+
+func TestWithTokenSourceRefreshes(t *testing.T) {
+	var auth []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = append(auth, r.Header.Get("Authorization"))
+	}))
+	defer server.Close()
+
+	source := &testTokenSource{}
+	spotify := NewSpotifyClient(
+		context.Background(),
+		WithBaseURL(server.URL),
+		WithTokenSource(source),
+	)
+
+	for i := 0; i < 2; i++ {
+		err := spotify.PausePlayback()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if auth[0] == auth[1] {
+		t.Errorf("Expected refreshed token, got %s twice", auth[0])
+	}
+}
+
+// testTokenSource returns a new token on every call, as if the previous one has expired.
+type testTokenSource struct {
+	calls int
+}
+
+func (s *testTokenSource) Token() (*oauth2.Token, error) {
+	s.calls++
+	return &oauth2.Token{
+		AccessToken: fmt.Sprintf("token-%d", s.calls),
+		TokenType:   "Bearer",
+		Expiry:      time.Now().Add(-time.Minute),
+	}, nil
+}
@@ -0,0 +1,357 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// The maximum number of the items, which are allocated in advance, while collecting the pages.
+	maxCollectCapacity = 1000
+	// The maximum offset Search accepts, even if the Total of its results is larger.
+	maxSearchOffset = 1000
+)
+
+// ErrStopPaging can be returned by the callback of the Paginator to stop paging early.
+// It is not returned to the caller.
+var ErrStopPaging = errors.New("spotify: stop paging")
+
+// pageOf is implemented by every XxxChunk type holding the items of type T.
+type pageOf[T any] interface {
+	page() *Chunk
+	items() []T
+}
+
+// cursorPage is implemented by every XxxCursorChunk type, through the embedded CursorChunk.
+type cursorPage interface {
+	cursorChunk() *CursorChunk
+}
+
+// Paginator walks all the pages of the paged set, such as the SavedTrackChunk,
+// by following the Next links of the pages, starting from the first one.
+// Pages addressed by the cursors, such as the RecentlyPlayedTracks, are walked the same way,
+// as their Next links carry the cursor of the page, or by their cursors in either direction, see FollowAfter and FollowBefore.
+// It is recommended to create Paginator through the NewPaginator function.
+type Paginator[T any] struct {
+	s *Spotify
+	// The page the paging starts from.
+	first pageOf[T]
+	// Creates the empty page of the same type as the first one, to decode the following pages into.
+	newPage func() pageOf[T]
+	// Returns the link to the page following the given one. If nil, the Next links are followed.
+	next func(page pageOf[T]) string
+}
+
+// NewPaginator creates the Paginator, which starts from the given page and fetches the following ones with the client.
+// The page can be any XxxChunk returned by the paged methods, for example:
+//
+//	tracks, err := spotify.GetUserSavedTracks(api.Limit(50))
+//	...
+//	all, err := api.NewPaginator(spotify, tracks).CollectAll(ctx, 0)
+func NewPaginator[T any, P any, C interface {
+	*P
+	pageOf[T]
+}](s *Spotify, first C) *Paginator[T] {
+	return &Paginator[T]{
+		s:     s,
+		first: first,
+		newPage: func() pageOf[T] {
+			return C(new(P))
+		},
+	}
+}
+
+// FollowAfter returns the copy of the Paginator, which walks the pages addressed by the cursors
+// through their Cursors.After instead of their Next links.
+// For example, it walks the recently played tracks forward in time, starting from the ones fetched with AfterTime.
+// Pages without the cursors are not walked past the first one.
+func (p *Paginator[T]) FollowAfter() *Paginator[T] {
+	return p.followCursor((*CursorChunk).AfterLink)
+}
+
+// FollowBefore returns the copy of the Paginator, which walks the pages addressed by the cursors
+// through their Cursors.Before instead of their Next links.
+// For example, it walks the recently played tracks backward in time, starting from the ones fetched with BeforeTime.
+// Pages without the cursors are not walked past the first one.
+func (p *Paginator[T]) FollowBefore() *Paginator[T] {
+	return p.followCursor((*CursorChunk).BeforeLink)
+}
+
+// followCursor returns the copy of the Paginator, which follows the links returned by the link func.
+func (p *Paginator[T]) followCursor(link func(c *CursorChunk) string) *Paginator[T] {
+	follow := *p
+	follow.next = func(page pageOf[T]) string {
+		if c, ok := page.(cursorPage); ok {
+			return link(c.cursorChunk())
+		}
+		return ""
+	}
+	return &follow
+}
+
+// ForEach calls the fn for every item of every page, fetching the pages as they are needed.
+// Paging stops at the first error, which is returned, unless it is ErrStopPaging.
+func (p *Paginator[T]) ForEach(ctx context.Context, fn func(item T) error) error {
+	err := p.walk(ctx, func(page pageOf[T]) error {
+		for _, item := range page.items() {
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, ErrStopPaging) {
+		return nil
+	}
+	return err
+}
+
+// CollectAll returns the items of all the pages, but no more than max of them.
+// Non-positive max collects every item.
+func (p *Paginator[T]) CollectAll(ctx context.Context, max int) ([]T, error) {
+	size := p.first.page().Total
+	if max > 0 && (size <= 0 || size > max) {
+		size = max
+	}
+	result := make([]T, 0, collectCapacity(size))
+
+	err := p.ForEach(ctx, func(item T) error {
+		result = append(result, item)
+		if max > 0 && len(result) >= max {
+			return ErrStopPaging
+		}
+		return nil
+	})
+	return result, err
+}
+
+// CollectParallel returns the items of all the pages, but no more than max of them, like CollectAll does.
+// Instead of following the Next links one by one, it uses the Total and Limit of the first page
+// to fetch the remaining pages at once, with up to workers requests in flight.
+// The items are returned in order. The requests pass through the rate limiter of the client, if any,
+// and the first error cancels the ones still in flight.
+// Pages of Search are not fetched past its offset limit, and if the page is refused with 400 Bad Request,
+// the items before it are returned, as Spotify stops serving the pages there, though the Total may be larger.
+// If the pages are not addressed by the offset, such as the ones using cursors, they are fetched one by one,
+// the same as when the Paginator follows the cursors.
+func (p *Paginator[T]) CollectParallel(ctx context.Context, max, workers int) ([]T, error) {
+	first := p.first.page()
+	next, err := url.Parse(first.Next)
+	if p.next != nil || err != nil || !next.Query().Has("offset") || first.Total <= 0 || first.Limit <= 0 {
+		return p.CollectAll(ctx, max)
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	end := first.Total
+	if max > 0 && first.Offset+max < end {
+		end = first.Offset + max
+	}
+	if strings.HasSuffix(next.Path, "/search") && end > maxSearchOffset {
+		end = maxSearchOffset
+	}
+	var offsets []int
+	for offset := first.Offset + first.Limit; offset < end; offset += first.Limit {
+		offsets = append(offsets, offset)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	pages := make([]pageOf[T], len(offsets))
+	errs := make([]error, len(offsets))
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	for i, offset := range offsets {
+		query := next.Query()
+		query.Set("offset", strconv.Itoa(offset))
+		pageUrl := *next
+		pageUrl.RawQuery = query.Encode()
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+			continue
+		}
+		wg.Add(1)
+		go func(i int, pageUrl string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			pages[i], errs[i] = p.fetch(ctx, pageUrl)
+			if errs[i] != nil && !isBadRequest(errs[i]) {
+				cancel()
+			}
+		}(i, pageUrl.String())
+	}
+	wg.Wait()
+
+	result := make([]T, 0, collectCapacity(end-first.Offset))
+	result = append(result, p.first.items()...)
+	for i, page := range pages {
+		if isBadRequest(errs[i]) {
+			break
+		}
+		if errs[i] != nil {
+			return nil, firstError(errs)
+		}
+		result = append(result, page.items()...)
+	}
+	if max > 0 && len(result) > max {
+		result = result[:max]
+	}
+	return result, nil
+}
+
+// collectCapacity returns the capacity the collected items are allocated with, given their expected number.
+// The Total reported by Spotify is not trusted for more than maxCollectCapacity items,
+// so the larger results grow as the pages arrive.
+func collectCapacity(size int) int {
+	if size > maxCollectCapacity {
+		return maxCollectCapacity
+	}
+	if size < 0 {
+		return 0
+	}
+	return size
+}
+
+// isBadRequest checks if the page was refused with 400 Bad Request, as the ones past the offset limit are.
+func isBadRequest(err error) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.Status == http.StatusBadRequest
+}
+
+// firstError returns the first error, which is not caused by the cancellation of the other requests.
+func firstError(errs []error) error {
+	var canceled error
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, context.Canceled) {
+			return err
+		}
+		if canceled == nil {
+			canceled = err
+		}
+	}
+	return canceled
+}
+
+// walk calls the fn for every page, starting from the first one.
+// It stops once the page has no link to the following one, has no items, or the Total number of items is reached.
+func (p *Paginator[T]) walk(ctx context.Context, fn func(page pageOf[T]) error) error {
+	page := p.first
+	for {
+		if err := fn(page); err != nil {
+			return err
+		}
+
+		chunk, count, link := page.page(), len(page.items()), p.nextLink(page)
+		if link == "" || count == 0 || (chunk.Total > 0 && chunk.Offset+count >= chunk.Total) {
+			return nil
+		}
+
+		next, err := p.fetch(ctx, link)
+		if err != nil {
+			return err
+		}
+		page = next
+	}
+}
+
+// nextLink returns the link to the page following the given one.
+func (p *Paginator[T]) nextLink(page pageOf[T]) string {
+	if p.next != nil {
+		return p.next(page)
+	}
+	return page.page().Next
+}
+
+// fetch gets the page from the link of the previous one.
+func (p *Paginator[T]) fetch(ctx context.Context, next string) (pageOf[T], error) {
+	page := p.newPage()
+	return page, getPage(ctx, p.s, next, page)
+}
+
+// fetchPage gets the page of type P from the given link, such as the Next link of the chunk.
+func fetchPage[P any](ctx context.Context, s *Spotify, link string) (*P, error) {
+	if link == "" {
+		return nil, ErrNoPage
+	}
+
+	page := new(P)
+	if err := getPage(ctx, s, link, page); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// getPage gets the page from the given link, decoding it into the page object.
+func getPage(ctx context.Context, s *Spotify, link string, page interface{}) error {
+	var raw json.RawMessage
+	err := s.Do(ctx, http.MethodGet, link, nil, &raw)
+	if err != nil {
+		return err
+	}
+	return decodePage(raw, page)
+}
+
+// decodePage decodes the page into the given object.
+// Some endpoints, such as Search or GetNewReleases, wrap the page into the object,
+// like {"albums": {...}}, or {"tracks": {...}, "albums": {...}} when several types are searched,
+// so such pages are unwrapped first, taking the field holding the pages of the given type.
+func decodePage(raw json.RawMessage, page interface{}) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return err
+	}
+	if _, ok := fields["items"]; ok {
+		return json.Unmarshal(raw, page)
+	}
+
+	wrapped, ok := fields[pageField(page)]
+	if !ok && len(fields) == 1 {
+		for _, field := range fields {
+			wrapped, ok = field, true
+		}
+	}
+	if !ok {
+		return fmt.Errorf("spotify: no %T in the page with %d fields", page, len(fields))
+	}
+	return json.Unmarshal(wrapped, page)
+}
+
+// pageField returns the field, which holds the pages of the given type in the wrapped responses,
+// such as the ones of Search.
+func pageField(page interface{}) string {
+	switch page.(type) {
+	case *FullTrackChunk:
+		return "tracks"
+	case *FullArtistChunk, *FullArtistCursorChunk:
+		return "artists"
+	case *SimplifiedAlbumChunk:
+		return "albums"
+	case *SimplifiedPlaylistChunk:
+		return "playlists"
+	case *SimplifiedShowChunk:
+		return "shows"
+	case *SimplifiedEpisodeChunk:
+		return "episodes"
+	case *SimplifiedAudiobookChunk:
+		return "audiobooks"
+	case *CategoryChunk:
+		return "categories"
+	}
+	return ""
+}
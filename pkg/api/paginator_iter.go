@@ -0,0 +1,31 @@
+//go:build go1.23
+
+package api
+
+import (
+	"context"
+	"iter"
+)
+
+// All returns the iterator over the items of every page, to be used with the range loop:
+//
+//	for track, err := range api.NewPaginator(spotify, tracks).All(ctx) {
+//		...
+//	}
+//
+// The pages are fetched as they are needed. If fetching fails, the error is yielded with the zero item,
+// and the iteration stops.
+func (p *Paginator[T]) All(ctx context.Context) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		err := p.ForEach(ctx, func(item T) error {
+			if !yield(item, nil) {
+				return ErrStopPaging
+			}
+			return nil
+		})
+		if err != nil {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+//go:build go1.23
+
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestPaginatorAll(t *testing.T) {
+	server, spotify, first, calls := testPaginator(t, "")
+	defer server.Close()
+
+	var ids []string
+	for track, err := range NewPaginator(spotify, first).All(context.Background()) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, track.Id)
+		if len(ids) == 3 {
+			break
+		}
+	}
+	if len(ids) != 3 || ids[2] != "track-2" {
+		t.Errorf("Expected the first 3 tracks, got %v", ids)
+	}
+	if *calls != 2 {
+		t.Errorf("Expected 2 calls, got %d", *calls)
+	}
+}
+
+func TestPaginatorAllError(t *testing.T) {
+	server, spotify := testServer(testErrorHandler(http.StatusNotFound, nil, ""))
+	defer server.Close()
+
+	first := &FullTrackChunk{
+		Chunk: Chunk{Next: server.URL + "/tracks?offset=1", Limit: 1, Total: 2},
+		Items: []FullTrack{{}},
+	}
+	var errs []error
+	for _, err := range NewPaginator(spotify, first).All(context.Background()) {
+		errs = append(errs, err)
+	}
+	if len(errs) != 2 || errs[0] != nil || !errors.Is(errs[1], ErrNotFound) {
+		t.Errorf("Expected the item followed by %v, got %v", ErrNotFound, errs)
+	}
+}
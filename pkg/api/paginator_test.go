@@ -0,0 +1,559 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testPageTotal = 5
+
+// testPageHandler serves the pages of testPageTotal tracks, limit of them at a time.
+// If wrap is not empty, pages are wrapped into the object with the single field, like the search results are.
+func testPageHandler(server **httptest.Server, calls *int32, wrap string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		if limit == 0 {
+			limit = 2
+		}
+
+		chunk := FullTrackChunk{Chunk: Chunk{Limit: limit, Offset: offset, Total: testPageTotal}}
+		for i := offset; i < offset+limit && i < testPageTotal; i++ {
+			chunk.Items = append(chunk.Items, FullTrack{SimplifiedTrack: SimplifiedTrack{
+				AudioRecording: AudioRecording{Id: fmt.Sprintf("track-%d", i)},
+			}})
+		}
+		if offset+limit < testPageTotal {
+			chunk.Next = fmt.Sprintf("%s%s?offset=%d&limit=%d", (*server).URL, r.URL.Path, offset+limit, limit)
+		}
+		if offset > 0 {
+			chunk.Previous = fmt.Sprintf("%s%s?offset=%d&limit=%d", (*server).URL, r.URL.Path, offset-limit, limit)
+		}
+
+		var body interface{} = chunk
+		if wrap != "" {
+			body = map[string]interface{}{wrap: chunk}
+		}
+		data, err := json.Marshal(body)
+		if err != nil {
+			panic(err)
+		}
+		if err := writeResponse(w, data); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func testPaginator(t *testing.T, wrap string) (*httptest.Server, *Spotify, *FullTrackChunk, *int32) {
+	t.Helper()
+	var calls int32
+	var server *httptest.Server
+	server, spotify := testServer(testPageHandler(&server, &calls, wrap))
+
+	first := &FullTrackChunk{}
+	if err := spotify.Get(first, "/tracks"); err != nil {
+		t.Fatal(err)
+	}
+	return server, spotify, first, &calls
+}
+
+func testTrackIds(tracks []FullTrack) []string {
+	ids := make([]string, len(tracks))
+	for i, track := range tracks {
+		ids[i] = track.Id
+	}
+	return ids
+}
+
+func TestPaginatorCollectAll(t *testing.T) {
+	server, spotify, first, calls := testPaginator(t, "")
+	defer server.Close()
+
+	tracks, err := NewPaginator(spotify, first).CollectAll(context.Background(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"track-0", "track-1", "track-2", "track-3", "track-4"}
+	if !reflect.DeepEqual(testTrackIds(tracks), expected) {
+		t.Errorf("Expected %v, got %v", expected, testTrackIds(tracks))
+	}
+	if *calls != 3 {
+		t.Errorf("Expected 3 calls, got %d", *calls)
+	}
+
+	tracks, err = NewPaginator(spotify, first).CollectAll(context.Background(), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(testTrackIds(tracks), expected[:3]) {
+		t.Errorf("Expected %v, got %v", expected[:3], testTrackIds(tracks))
+	}
+	if *calls != 4 {
+		t.Errorf("Expected pages after the cap not to be fetched, got %d calls", *calls)
+	}
+
+	tracks, err = NewPaginator(spotify, first).CollectAll(context.Background(), 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(testTrackIds(tracks), expected[:4]) {
+		t.Errorf("Expected %v, got %v", expected[:4], testTrackIds(tracks))
+	}
+	if *calls != 5 {
+		t.Errorf("Expected the page after the cap on the page boundary not to be fetched, got %d calls", *calls)
+	}
+}
+
+func TestPaginatorCollectCapacity(t *testing.T) {
+	first := &FullTrackChunk{Chunk: Chunk{Limit: 1, Total: 1 << 40}, Items: []FullTrack{{}}}
+	tracks, err := NewPaginator(nil, first).CollectAll(context.Background(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tracks) != 1 || cap(tracks) > maxCollectCapacity {
+		t.Errorf("Expected the capacity to be capped at %d, got %d", maxCollectCapacity, cap(tracks))
+	}
+	if size := collectCapacity(10); size != 10 {
+		t.Errorf("Expected the capacity of 10, got %d", size)
+	}
+}
+
+func TestPaginatorWrappedPages(t *testing.T) {
+	var calls int32
+	var server *httptest.Server
+	server, spotify := testServer(testPageHandler(&server, &calls, "tracks"))
+	defer server.Close()
+
+	result, err := spotify.Search("test", []string{"track"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tracks, err := NewPaginator(spotify, &result.Tracks).CollectAll(context.Background(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tracks) != testPageTotal || tracks[4].Id != "track-4" {
+		t.Errorf("Expected %d tracks, got %v", testPageTotal, testTrackIds(tracks))
+	}
+}
+
+func TestPaginatorSearchSeveralTypes(t *testing.T) {
+	var calls int32
+	var server *httptest.Server
+	server, spotify := testServer(func(w http.ResponseWriter, r *http.Request) {
+		rec := httptest.NewRecorder()
+		testPageHandler(&server, &calls, "")(rec, r)
+		data := `{"tracks":` + rec.Body.String() + `,"albums":{"items":[{"id":"album-0"}],"total":1}}`
+		if err := writeResponse(w, []byte(data)); err != nil {
+			panic(err)
+		}
+	})
+	defer server.Close()
+
+	result, err := spotify.Search("test", []string{"track", "album"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"track-0", "track-1", "track-2", "track-3", "track-4"}
+	tracks, err := NewPaginator(spotify, &result.Tracks).CollectAll(context.Background(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(testTrackIds(tracks), expected) {
+		t.Errorf("Expected %v, got %v", expected, testTrackIds(tracks))
+	}
+
+	tracks, err = NewPaginator(spotify, &result.Tracks).CollectParallel(context.Background(), 0, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(testTrackIds(tracks), expected) {
+		t.Errorf("Expected %v in parallel, got %v", expected, testTrackIds(tracks))
+	}
+
+	next, err := result.Tracks.NextPage(context.Background(), spotify)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(testTrackIds(next.Items), expected[2:4]) {
+		t.Errorf("Expected %v, got %v", expected[2:4], testTrackIds(next.Items))
+	}
+}
+
+func TestDecodePageUnknownShape(t *testing.T) {
+	page := &FullTrackChunk{}
+	err := decodePage([]byte(`{"albums":{"items":[]},"artists":{"items":[]}}`), page)
+	if err == nil {
+		t.Errorf("Expected the page without tracks to fail, got %v", page)
+	}
+}
+
+func TestPaginatorForEach(t *testing.T) {
+	server, spotify, first, _ := testPaginator(t, "")
+	defer server.Close()
+
+	var ids []string
+	err := NewPaginator(spotify, first).ForEach(context.Background(), func(track FullTrack) error {
+		ids = append(ids, track.Id)
+		if len(ids) == 3 {
+			return ErrStopPaging
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 3 {
+		t.Errorf("Expected paging to stop after 3 items, got %v", ids)
+	}
+
+	failure := errors.New("failure")
+	err = NewPaginator(spotify, first).ForEach(context.Background(), func(track FullTrack) error {
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Errorf("Expected %v, got %v", failure, err)
+	}
+}
+
+func TestPaginatorFetchError(t *testing.T) {
+	server, spotify := testServer(testErrorHandler(http.StatusNotFound, nil, ""))
+	defer server.Close()
+
+	first := &FullTrackChunk{
+		Chunk: Chunk{Next: server.URL + "/tracks?offset=1", Limit: 1, Total: 2},
+		Items: []FullTrack{{}},
+	}
+	tracks, err := NewPaginator(spotify, first).CollectAll(context.Background(), 0)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected %v, got %v", ErrNotFound, err)
+	}
+	if len(tracks) != 1 {
+		t.Errorf("Expected items of the first page, got %d", len(tracks))
+	}
+}
+
+func TestPaginatorCollectParallel(t *testing.T) {
+	var calls, inFlight, maxInFlight int32
+	var server *httptest.Server
+	server, spotify := testServer(
+		testPageHandler(&server, &calls, ""),
+		WithMiddleware(func(next Handler) Handler {
+			return func(ctx context.Context, req *Request) (*Response, error) {
+				current := atomic.AddInt32(&inFlight, 1)
+				defer atomic.AddInt32(&inFlight, -1)
+				for {
+					seen := atomic.LoadInt32(&maxInFlight)
+					if current <= seen || atomic.CompareAndSwapInt32(&maxInFlight, seen, current) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+				return next(ctx, req)
+			}
+		}),
+	)
+	defer server.Close()
+
+	first := &FullTrackChunk{}
+	if err := spotify.Get(first, "/tracks", Limit(1)); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"track-0", "track-1", "track-2", "track-3", "track-4"}
+	for _, workers := range []int{1, 2} {
+		atomic.StoreInt32(&maxInFlight, 0)
+		tracks, err := NewPaginator(spotify, first).CollectParallel(context.Background(), 0, workers)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(testTrackIds(tracks), expected) {
+			t.Errorf("Expected %v, got %v", expected, testTrackIds(tracks))
+		}
+		if maxInFlight > int32(workers) {
+			t.Errorf("Expected at most %d requests in flight, got %d", workers, maxInFlight)
+		}
+	}
+	if calls != 9 {
+		t.Errorf("Expected 9 calls, got %d", calls)
+	}
+
+	tracks, err := NewPaginator(spotify, first).CollectParallel(context.Background(), 3, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(testTrackIds(tracks), expected[:3]) {
+		t.Errorf("Expected %v, got %v", expected[:3], testTrackIds(tracks))
+	}
+	if calls != 11 {
+		t.Errorf("Expected pages after the cap not to be fetched, got %d calls", calls)
+	}
+}
+
+func TestPaginatorCollectParallelError(t *testing.T) {
+	server, spotify := testServer(testErrorHandler(http.StatusNotFound, nil, ""))
+	defer server.Close()
+
+	first := &FullTrackChunk{
+		Chunk: Chunk{Next: server.URL + "/tracks?offset=1&limit=1", Limit: 1, Total: 10},
+		Items: []FullTrack{{}},
+	}
+	tracks, err := NewPaginator(spotify, first).CollectParallel(context.Background(), 0, 3)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected %v, got %v", ErrNotFound, err)
+	}
+	if tracks != nil {
+		t.Errorf("Expected no tracks, got %d", len(tracks))
+	}
+}
+
+func TestPaginatorCollectParallelOffsetLimit(t *testing.T) {
+	var calls int32
+	var server *httptest.Server
+	server, spotify := testServer(func(w http.ResponseWriter, r *http.Request) {
+		if offset, _ := strconv.Atoi(r.URL.Query().Get("offset")); offset >= 4 {
+			testErrorHandler(http.StatusBadRequest, nil, `{"error":{"status":400,"message":"Invalid offset"}}`)(w, r)
+			return
+		}
+		testPageHandler(&server, &calls, "")(w, r)
+	})
+	defer server.Close()
+
+	first := &FullTrackChunk{
+		Chunk: Chunk{Next: server.URL + "/tracks?offset=2&limit=2", Limit: 2, Total: 10},
+		Items: []FullTrack{
+			{SimplifiedTrack: SimplifiedTrack{AudioRecording: AudioRecording{Id: "track-0"}}},
+			{SimplifiedTrack: SimplifiedTrack{AudioRecording: AudioRecording{Id: "track-1"}}},
+		},
+	}
+	tracks, err := NewPaginator(spotify, first).CollectParallel(context.Background(), 0, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"track-0", "track-1", "track-2", "track-3"}
+	if !reflect.DeepEqual(testTrackIds(tracks), expected) {
+		t.Errorf("Expected %v, got %v", expected, testTrackIds(tracks))
+	}
+}
+
+func TestPaginatorCollectParallelSearchLimit(t *testing.T) {
+	var calls, maxOffset int32
+	server, spotify := testServer(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		for {
+			seen := atomic.LoadInt32(&maxOffset)
+			if int32(offset) <= seen || atomic.CompareAndSwapInt32(&maxOffset, seen, int32(offset)) {
+				break
+			}
+		}
+		if err := writeResponse(w, []byte(`{"tracks":{"items":[{"id":"track"}],"total":5000}}`)); err != nil {
+			panic(err)
+		}
+	})
+	defer server.Close()
+
+	first := &FullTrackChunk{
+		Chunk: Chunk{Next: server.URL + "/search?q=test&type=track&offset=50&limit=50", Limit: 50, Total: 5000},
+		Items: []FullTrack{{}},
+	}
+	if _, err := NewPaginator(spotify, first).CollectParallel(context.Background(), 0, 4); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 19 || maxOffset != 950 {
+		t.Errorf("Expected 19 pages up to the offset 950, got %d pages up to %d", calls, maxOffset)
+	}
+}
+
+func TestCursorPaginator(t *testing.T) {
+	artists := []string{"artist-0", "artist-1", "artist-2", "artist-3", "artist-4"}
+	var server *httptest.Server
+	server, spotify := testServer(func(w http.ResponseWriter, r *http.Request) {
+		start := 0
+		if after := r.URL.Query().Get("after"); after != "" {
+			for i, id := range artists {
+				if id == after {
+					start = i + 1
+				}
+			}
+		}
+		end := start + 2
+		if end > len(artists) {
+			end = len(artists)
+		}
+
+		chunk := &FullArtistCursorChunk{CursorChunk: CursorChunk{Limit: 2, Total: len(artists)}}
+		for _, id := range artists[start:end] {
+			chunk.Items = append(chunk.Items, FullArtist{SimplifiedArtist: SimplifiedArtist{Id: id}})
+		}
+		if end < len(artists) {
+			chunk.Cursors.After = artists[end-1]
+			chunk.Next = fmt.Sprintf("%s/me/following?type=artist&limit=2&after=%s", server.URL, chunk.Cursors.After)
+		}
+		data, err := json.Marshal(map[string]interface{}{"artists": chunk})
+		if err != nil {
+			panic(err)
+		}
+		if err := writeResponse(w, data); err != nil {
+			panic(err)
+		}
+	})
+	defer server.Close()
+
+	first, err := spotify.GetFollowedArtists("artist", Limit(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Cursors.After != "artist-1" {
+		t.Errorf("Expected cursor artist-1, got %s", first.Cursors.After)
+	}
+
+	collected, err := NewPaginator(spotify, first).CollectParallel(context.Background(), 0, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := make([]string, len(collected))
+	for i, artist := range collected {
+		ids[i] = artist.Id
+	}
+	if !reflect.DeepEqual(ids, artists) {
+		t.Errorf("Expected %v, got %v", artists, ids)
+	}
+}
+
+func TestRecentlyPlayedTimeBounds(t *testing.T) {
+	var query url.Values
+	server, spotify := testServer(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		err := writeResponse(w, []byte(`{"items":[{"played_at":"2024-07-13T10:00:00Z"}]}`))
+		if err != nil {
+			panic(err)
+		}
+	})
+	defer server.Close()
+
+	before := time.Date(2024, 7, 14, 0, 0, 0, 0, time.UTC)
+	tracks, err := spotify.GetRecentlyPlayedTracks(BeforeTime(before))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if query.Get("before") != strconv.FormatInt(before.UnixMilli(), 10) {
+		t.Errorf("Unexpected before cursor: %s", query.Get("before"))
+	}
+
+	playedAt, err := tracks.Items[0].PlayedAtTime()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !playedAt.Equal(time.Date(2024, 7, 13, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected played at: %v", playedAt)
+	}
+}
+
+// testPlayedHandler serves the tracks played at the given seconds, two at a time and the latest first,
+// addressing the pages by the after and before cursors in milliseconds, like the recently played tracks are.
+func testPlayedHandler(played []int) http.HandlerFunc {
+	base := time.Date(2024, 7, 13, 0, 0, 0, 0, time.UTC)
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		var page []int
+		if before := query.Get("before"); before != "" {
+			cursor, _ := strconv.ParseInt(before, 10, 64)
+			for i := len(played) - 1; i >= 0 && len(page) < 2; i-- {
+				if base.Add(time.Duration(played[i])*time.Second).UnixMilli() < cursor {
+					page = append(page, played[i])
+				}
+			}
+		} else {
+			cursor, _ := strconv.ParseInt(query.Get("after"), 10, 64)
+			for i := 0; i < len(played) && len(page) < 2; i++ {
+				if base.Add(time.Duration(played[i])*time.Second).UnixMilli() > cursor {
+					page = append([]int{played[i]}, page...)
+				}
+			}
+		}
+
+		tracks := RecentlyPlayedTracks{CursorChunk: CursorChunk{Href: "http://" + r.Host + r.URL.RequestURI(), Limit: 2}}
+		for _, second := range page {
+			playedAt := base.Add(time.Duration(second) * time.Second)
+			tracks.Items = append(tracks.Items, PlayHistory{PlayedAt: playedAt.Format(time.RFC3339)})
+		}
+		if len(page) > 0 {
+			tracks.Cursors.After = strconv.FormatInt(base.Add(time.Duration(page[0])*time.Second).UnixMilli(), 10)
+			tracks.Cursors.Before = strconv.FormatInt(base.Add(time.Duration(page[len(page)-1])*time.Second).UnixMilli(), 10)
+		}
+		data, err := json.Marshal(tracks)
+		if err != nil {
+			panic(err)
+		}
+		if err := writeResponse(w, data); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func testPlayedSeconds(t *testing.T, tracks []PlayHistory) []int {
+	t.Helper()
+	base := time.Date(2024, 7, 13, 0, 0, 0, 0, time.UTC)
+	seconds := make([]int, len(tracks))
+	for i, track := range tracks {
+		playedAt, err := track.PlayedAtTime()
+		if err != nil {
+			t.Fatal(err)
+		}
+		seconds[i] = int(playedAt.Sub(base) / time.Second)
+	}
+	return seconds
+}
+
+func TestCursorPaginatorDirections(t *testing.T) {
+	server, spotify := testServer(testPlayedHandler([]int{1, 2, 3, 4, 5, 6}))
+	defer server.Close()
+	base := time.Date(2024, 7, 13, 0, 0, 0, 0, time.UTC)
+
+	first, err := spotify.GetRecentlyPlayedTracks(AfterTime(base.Add(time.Second)), Limit(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tracks, err := NewPaginator(spotify, first).FollowAfter().CollectParallel(context.Background(), 0, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []int{3, 2, 5, 4, 6}; !reflect.DeepEqual(testPlayedSeconds(t, tracks), expected) {
+		t.Errorf("Expected %v walking forward, got %v", expected, testPlayedSeconds(t, tracks))
+	}
+
+	first, err = spotify.GetRecentlyPlayedTracks(BeforeTime(base.Add(5*time.Second)), Limit(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tracks, err = NewPaginator(spotify, first).FollowBefore().CollectAll(context.Background(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []int{4, 3, 2, 1}; !reflect.DeepEqual(testPlayedSeconds(t, tracks), expected) {
+		t.Errorf("Expected %v walking backward, got %v", expected, testPlayedSeconds(t, tracks))
+	}
+
+	previous, err := first.BeforePage(context.Background(), spotify)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []int{2, 1}; !reflect.DeepEqual(testPlayedSeconds(t, previous.Items), expected) {
+		t.Errorf("Expected %v, got %v", expected, testPlayedSeconds(t, previous.Items))
+	}
+	if _, err := (&RecentlyPlayedTracks{}).AfterPage(context.Background(), spotify); !errors.Is(err, ErrNoPage) {
+		t.Errorf("Expected %v, got %v", ErrNoPage, err)
+	}
+}
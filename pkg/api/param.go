@@ -3,6 +3,7 @@ package api
 import (
 	"net/url"
 	"strconv"
+	"time"
 )
 
 // Param used to dynamically add Parameters to endpoint URL.
@@ -97,22 +98,36 @@ func Limit(num int) Param {
 	}
 }
 
-// A Unix timestamp in milliseconds. Returns all items after (but not including) this cursor position.
+// The cursor, such as the Cursors.After of the previous page. Returns all items after (but not including) this cursor position.
+// For the recently played tracks, the cursor is a Unix timestamp in milliseconds, see AfterTime.
 // If after is specified, before must not be specified.
-func After(num int) Param {
+func After(cursor string) Param {
 	return func(v *url.Values) {
-		v.Add("after", strconv.Itoa(num))
+		v.Add("after", cursor)
 	}
 }
 
-// A  Unix timestamp in milliseconds. Returns all items before (but not including) this cursor position.
+// The cursor, such as the Cursors.Before of the previous page. Returns all items before (but not including) this cursor position.
+// For the recently played tracks, the cursor is a Unix timestamp in milliseconds, see BeforeTime.
 // If before is specified, after must not be specified.
-func Before(num int) Param {
+func Before(cursor string) Param {
 	return func(v *url.Values) {
-		v.Add("before", strconv.Itoa(num))
+		v.Add("before", cursor)
 	}
 }
 
+// Returns all items played after (but not including) the given time.
+// If after is specified, before must not be specified.
+func AfterTime(t time.Time) Param {
+	return After(strconv.FormatInt(t.UnixMilli(), 10))
+}
+
+// Returns all items played before (but not including) the given time.
+// If before is specified, after must not be specified.
+func BeforeTime(t time.Time) Param {
+	return Before(strconv.FormatInt(t.UnixMilli(), 10))
+}
+
 // The index of the first item to return. Default: 0 (the first item). Use with limit to get the next set of items.
 func Offset(num int) Param {
 	return func(v *url.Values) {
@@ -598,6 +613,14 @@ func TargetValence(num float64) Param {
 	}
 }
 
+// QueryParam adds the query parameter, which has no dedicated Param,
+// such as the one of the endpoint called through Do.
+func QueryParam(key, value string) Param {
+	return func(v *url.Values) {
+		v.Add(key, value)
+	}
+}
+
 // buildUrl adds given params to the given path by parsing path into the url
 func buildUrl(path string, params ...Param) (string, error) {
 	parsedUrl, err := url.Parse(path)
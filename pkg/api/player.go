@@ -1,7 +1,9 @@
 package api
 
 import (
+	"context"
 	"fmt"
+	"time"
 )
 
 // Deivce contains the device data that can be returned by the Spotify API.
@@ -106,20 +108,39 @@ type PlayHistory struct {
 	Context Context `json:"context"`
 }
 
+// PlayedAtTime parses the date and time the track was played.
+func (h *PlayHistory) PlayedAtTime() (time.Time, error) {
+	return time.Parse(time.RFC3339, h.PlayedAt)
+}
+
 // RecentlyPlayedTracks represents a paged set of PlayHistory items
 type RecentlyPlayedTracks struct {
-	// A link to the Web API endpoint returning the full result of the request.
-	Href string `json:"href"`
-	// The maximum number of items in the response (as set in the query or by default).
-	Limit int `json:"limit"`
-	// URL to the next page of items. ( null if none)
-	Next    string  `json:"next"`
-	Cursors Cursors `json:"cursors"`
-	// URL to the next page of items. ( null if none)
-	Total int           `json:"total"`
+	CursorChunk
 	Items []PlayHistory `json:"items"`
 }
 
+// items returns the PlayHistory items of the page.
+func (c *RecentlyPlayedTracks) items() []PlayHistory {
+	return c.Items
+}
+
+// NextPage fetches the page after this one with the client, or returns ErrNoPage if there is none.
+func (c *RecentlyPlayedTracks) NextPage(ctx context.Context, s *Spotify) (*RecentlyPlayedTracks, error) {
+	return fetchPage[RecentlyPlayedTracks](ctx, s, c.Next)
+}
+
+// AfterPage fetches the tracks played after the ones of this page, addressed by its Cursors.After,
+// or returns ErrNoPage if there is no such cursor.
+func (c *RecentlyPlayedTracks) AfterPage(ctx context.Context, s *Spotify) (*RecentlyPlayedTracks, error) {
+	return fetchPage[RecentlyPlayedTracks](ctx, s, c.AfterLink())
+}
+
+// BeforePage fetches the tracks played before the ones of this page, addressed by its Cursors.Before,
+// or returns ErrNoPage if there is no such cursor.
+func (c *RecentlyPlayedTracks) BeforePage(ctx context.Context, s *Spotify) (*RecentlyPlayedTracks, error) {
+	return fetchPage[RecentlyPlayedTracks](ctx, s, c.BeforeLink())
+}
+
 // UserQueue containts the user queue data that can be returned by the Spotify API.
 type UserQueue struct {
 	// The currently playing track or episode. Can be null.
@@ -135,8 +156,13 @@ type UserQueue struct {
 //
 // Scopes: ScopeUserReadPlaybackState.
 func (s *Spotify) GetPlaybackState(params ...Param) (*Playback, error) {
+	return s.GetPlaybackStateCtx(context.Background(), params...)
+}
+
+// GetPlaybackStateCtx is the same as GetPlaybackState, but it sends the request with the given context.
+func (s *Spotify) GetPlaybackStateCtx(ctx context.Context, params ...Param) (*Playback, error) {
 	playback := &Playback{}
-	err := s.Get(playback, "/me/player", params...)
+	err := s.GetCtx(ctx, playback, "/me/player", params...)
 	return playback, err
 }
 
@@ -148,11 +174,20 @@ func (s *Spotify) GetPlaybackState(params ...Param) (*Playback, error) {
 //
 // Scopes: ScopeUserModifyPlaybackState.
 func (s *Spotify) TransferPlayback(deviceIds Property, properties []Property) error {
+	return s.TransferPlaybackCtx(context.Background(), deviceIds, properties)
+}
+
+// TransferPlaybackCtx is the same as TransferPlayback, but it sends the request with the given context.
+func (s *Spotify) TransferPlaybackCtx(
+	ctx context.Context,
+	deviceIds Property,
+	properties []Property,
+) error {
 	body, err := createBodyFromProperties(append(properties, deviceIds))
 	if err != nil {
 		return err
 	}
-	return s.Put(nil, "/me/player", body)
+	return s.PutCtx(ctx, nil, "/me/player", body)
 }
 
 // GetAvailableDevices obtains information about a user’s available Spotify Connect devices.
@@ -160,10 +195,15 @@ func (s *Spotify) TransferPlayback(deviceIds Property, properties []Property) er
 //
 // Scopes: ScopeUserReadPlaybackState.
 func (s *Spotify) GetAvailableDevices() ([]*Device, error) {
+	return s.GetAvailableDevicesCtx(context.Background())
+}
+
+// GetAvailableDevicesCtx is the same as GetAvailableDevices, but it sends the request with the given context.
+func (s *Spotify) GetAvailableDevicesCtx(ctx context.Context) ([]*Device, error) {
 	var w struct {
 		Devices []*Device `json:"devices"`
 	}
-	err := s.Get(&w, "/me/player/devices")
+	err := s.GetCtx(ctx, &w, "/me/player/devices")
 	return w.Devices, err
 }
 
@@ -173,8 +213,16 @@ func (s *Spotify) GetAvailableDevices() ([]*Device, error) {
 //
 // Scopes: ScopeUserReadCurrentlyPlaying.
 func (s *Spotify) GetCurrentlyPlayingTrack(params ...Param) (*Playback, error) {
+	return s.GetCurrentlyPlayingTrackCtx(context.Background(), params...)
+}
+
+// GetCurrentlyPlayingTrackCtx is the same as GetCurrentlyPlayingTrack, but it sends the request with the given context.
+func (s *Spotify) GetCurrentlyPlayingTrackCtx(
+	ctx context.Context,
+	params ...Param,
+) (*Playback, error) {
 	playback := &Playback{}
-	err := s.Get(playback, "/me/player/currently-playing", params...)
+	err := s.GetCtx(ctx, playback, "/me/player/currently-playing", params...)
 	return playback, err
 }
 
@@ -188,11 +236,20 @@ func (s *Spotify) GetCurrentlyPlayingTrack(params ...Param) (*Playback, error) {
 //
 // Scopes: ScopeUserModifyPlaybackState.
 func (s *Spotify) StartResumePlayback(properties []Property, params ...Param) error {
+	return s.StartResumePlaybackCtx(context.Background(), properties, params...)
+}
+
+// StartResumePlaybackCtx is the same as StartResumePlayback, but it sends the request with the given context.
+func (s *Spotify) StartResumePlaybackCtx(
+	ctx context.Context,
+	properties []Property,
+	params ...Param,
+) error {
 	body, err := createBodyFromProperties(properties)
 	if err != nil {
 		return err
 	}
-	return s.Put(nil, "/me/player/play", body, params...)
+	return s.PutCtx(ctx, nil, "/me/player/play", body, params...)
 }
 
 // PausePlayback pauses playback on the user's account.
@@ -203,7 +260,12 @@ func (s *Spotify) StartResumePlayback(properties []Property, params ...Param) er
 //
 // Scopes: ScopeUserModifyPlaybackState.
 func (s *Spotify) PausePlayback(params ...Param) error {
-	return s.Put(nil, "/me/player/pause", []byte{}, params...)
+	return s.PausePlaybackCtx(context.Background(), params...)
+}
+
+// PausePlaybackCtx is the same as PausePlayback, but it sends the request with the given context.
+func (s *Spotify) PausePlaybackCtx(ctx context.Context, params ...Param) error {
+	return s.PutCtx(ctx, nil, "/me/player/pause", []byte{}, params...)
 }
 
 // SkipToNext skips to next track in the user’s queue.
@@ -214,7 +276,12 @@ func (s *Spotify) PausePlayback(params ...Param) error {
 //
 // Scopes: ScopeUserModifyPlaybackState.
 func (s *Spotify) SkipToNext(params ...Param) error {
-	return s.Put(nil, "/me/player/next", []byte{}, params...)
+	return s.SkipToNextCtx(context.Background(), params...)
+}
+
+// SkipToNextCtx is the same as SkipToNext, but it sends the request with the given context.
+func (s *Spotify) SkipToNextCtx(ctx context.Context, params ...Param) error {
+	return s.PutCtx(ctx, nil, "/me/player/next", []byte{}, params...)
 }
 
 // SkipToPrevious skips to previous track in the user’s queue.
@@ -225,7 +292,12 @@ func (s *Spotify) SkipToNext(params ...Param) error {
 //
 // Scopes: ScopeUserModifyPlaybackState.
 func (s *Spotify) SkipToPrevious(params ...Param) error {
-	return s.Put(nil, "/me/player/previous", []byte{}, params...)
+	return s.SkipToPreviousCtx(context.Background(), params...)
+}
+
+// SkipToPreviousCtx is the same as SkipToPrevious, but it sends the request with the given context.
+func (s *Spotify) SkipToPreviousCtx(ctx context.Context, params ...Param) error {
+	return s.PutCtx(ctx, nil, "/me/player/previous", []byte{}, params...)
 }
 
 // SeekToPosition seeks o the given position in the user’s currently playing track.
@@ -236,7 +308,13 @@ func (s *Spotify) SkipToPrevious(params ...Param) error {
 //
 // Scopes: ScopeUserModifyPlaybackState.
 func (s *Spotify) SeekToPosition(positionMs int, params ...Param) error {
-	return s.Put(
+	return s.SeekToPositionCtx(context.Background(), positionMs, params...)
+}
+
+// SeekToPositionCtx is the same as SeekToPosition, but it sends the request with the given context.
+func (s *Spotify) SeekToPositionCtx(ctx context.Context, positionMs int, params ...Param) error {
+	return s.PutCtx(
+		ctx,
 		nil,
 		fmt.Sprintf("/me/player/seek?position_ms=%d", positionMs),
 		[]byte{},
@@ -251,7 +329,12 @@ func (s *Spotify) SeekToPosition(positionMs int, params ...Param) error {
 //
 // Scopes: ScopeUserModifyPlaybackState.
 func (s *Spotify) SetRepeatMode(state string, params ...Param) error {
-	return s.Put(nil, fmt.Sprintf("/me/player/repeat?state=%s", state), []byte{}, params...)
+	return s.SetRepeatModeCtx(context.Background(), state, params...)
+}
+
+// SetRepeatModeCtx is the same as SetRepeatMode, but it sends the request with the given context.
+func (s *Spotify) SetRepeatModeCtx(ctx context.Context, state string, params ...Param) error {
+	return s.PutCtx(ctx, nil, fmt.Sprintf("/me/player/repeat?state=%s", state), []byte{}, params...)
 }
 
 // SetPlaybackVolume sets the volume for the user’s current playback device.
@@ -262,7 +345,17 @@ func (s *Spotify) SetRepeatMode(state string, params ...Param) error {
 //
 // Scopes: ScopeUserModifyPlaybackState.
 func (s *Spotify) SetPlaybackVolume(volumePercent int, params ...Param) error {
-	return s.Put(
+	return s.SetPlaybackVolumeCtx(context.Background(), volumePercent, params...)
+}
+
+// SetPlaybackVolumeCtx is the same as SetPlaybackVolume, but it sends the request with the given context.
+func (s *Spotify) SetPlaybackVolumeCtx(
+	ctx context.Context,
+	volumePercent int,
+	params ...Param,
+) error {
+	return s.PutCtx(
+		ctx,
 		nil,
 		fmt.Sprintf("/me/player/volume?volume_percent=%d", volumePercent),
 		[]byte{},
@@ -277,7 +370,13 @@ func (s *Spotify) SetPlaybackVolume(volumePercent int, params ...Param) error {
 //
 // Scopes: ScopeUserModifyPlaybackState.
 func (s *Spotify) TogglePlaybackShuffle(state bool, params ...Param) error {
-	return s.Put(
+	return s.TogglePlaybackShuffleCtx(context.Background(), state, params...)
+}
+
+// TogglePlaybackShuffleCtx is the same as TogglePlaybackShuffle, but it sends the request with the given context.
+func (s *Spotify) TogglePlaybackShuffleCtx(ctx context.Context, state bool, params ...Param) error {
+	return s.PutCtx(
+		ctx,
 		nil,
 		fmt.Sprintf("/me/player/shuffle?boolean=%t", state),
 		[]byte{},
@@ -288,12 +387,21 @@ func (s *Spotify) TogglePlaybackShuffle(state bool, params ...Param) error {
 //
 // Note: Currently doesn't support podcast episodes.
 //
-// Params: Limit, After, Before.
+// Params: Limit, After, Before, AfterTime, BeforeTime.
+// The following pages can be fetched with the Paginator.
 //
 // Scopes: ScopeUserReadRecentlyPlayed.
 func (s *Spotify) GetRecentlyPlayedTracks(params ...Param) (*RecentlyPlayedTracks, error) {
+	return s.GetRecentlyPlayedTracksCtx(context.Background(), params...)
+}
+
+// GetRecentlyPlayedTracksCtx is the same as GetRecentlyPlayedTracks, but it sends the request with the given context.
+func (s *Spotify) GetRecentlyPlayedTracksCtx(
+	ctx context.Context,
+	params ...Param,
+) (*RecentlyPlayedTracks, error) {
 	tracks := &RecentlyPlayedTracks{}
-	err := s.Get(tracks, "/me/player/recently-played", params...)
+	err := s.GetCtx(ctx, tracks, "/me/player/recently-played", params...)
 	return tracks, err
 }
 
@@ -301,8 +409,13 @@ func (s *Spotify) GetRecentlyPlayedTracks(params ...Param) (*RecentlyPlayedTrack
 //
 // Scopes: UserReadCurrentlyPlaying, UserReadPlaybackState.
 func (s *Spotify) GetUserQueue() (*UserQueue, error) {
+	return s.GetUserQueueCtx(context.Background())
+}
+
+// GetUserQueueCtx is the same as GetUserQueue, but it sends the request with the given context.
+func (s *Spotify) GetUserQueueCtx(ctx context.Context) (*UserQueue, error) {
 	queue := &UserQueue{}
-	err := s.Get(queue, "/me/player/queue")
+	err := s.GetCtx(ctx, queue, "/me/player/queue")
 	return queue, err
 }
 
@@ -314,5 +427,14 @@ func (s *Spotify) GetUserQueue() (*UserQueue, error) {
 //
 // Scopes: ScopeUserModifyPlaybackState
 func (s *Spotify) AddItemToPlaybackQueue(URI string, params ...Param) error {
-	return s.Put(nil, fmt.Sprintf("/me/player/queue?uri=%s", URI), []byte{}, params...)
+	return s.AddItemToPlaybackQueueCtx(context.Background(), URI, params...)
+}
+
+// AddItemToPlaybackQueueCtx is the same as AddItemToPlaybackQueue, but it sends the request with the given context.
+func (s *Spotify) AddItemToPlaybackQueueCtx(
+	ctx context.Context,
+	URI string,
+	params ...Param,
+) error {
+	return s.PutCtx(ctx, nil, fmt.Sprintf("/me/player/queue?uri=%s", URI), []byte{}, params...)
 }
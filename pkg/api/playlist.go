@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -97,8 +98,17 @@ type Snapshot struct {
 //
 // Params: Market, Fields, AdditionalTypes.
 func (s *Spotify) GetPlaylist(id string, params ...Param) (*FullPlaylist, error) {
+	return s.GetPlaylistCtx(context.Background(), id, params...)
+}
+
+// GetPlaylistCtx is the same as GetPlaylist, but it sends the request with the given context.
+func (s *Spotify) GetPlaylistCtx(
+	ctx context.Context,
+	id string,
+	params ...Param,
+) (*FullPlaylist, error) {
 	playlist := &FullPlaylist{}
-	err := s.Get(playlist, fmt.Sprintf("/playlists/%s", id), params...)
+	err := s.GetCtx(ctx, playlist, fmt.Sprintf("/playlists/%s", id), params...)
 	return playlist, err
 }
 
@@ -109,11 +119,20 @@ func (s *Spotify) GetPlaylist(id string, params ...Param) (*FullPlaylist, error)
 //
 // Scopes: ScopePlaylistModifyPublic, ScopePlaylistModifyPrivate.
 func (s *Spotify) ChangePlaylistDetails(id string, properties []Property) error {
+	return s.ChangePlaylistDetailsCtx(context.Background(), id, properties)
+}
+
+// ChangePlaylistDetailsCtx is the same as ChangePlaylistDetails, but it sends the request with the given context.
+func (s *Spotify) ChangePlaylistDetailsCtx(
+	ctx context.Context,
+	id string,
+	properties []Property,
+) error {
 	body, err := createBodyFromProperties(properties)
 	if err != nil {
 		return err
 	}
-	return s.Put(nil, fmt.Sprintf("/playlist/%s", id), body)
+	return s.PutCtx(ctx, nil, fmt.Sprintf("/playlist/%s", id), body)
 }
 
 // GetPlaylistItems obtains full details of the items of a playlist owned by a Spotify user.
@@ -122,8 +141,17 @@ func (s *Spotify) ChangePlaylistDetails(id string, properties []Property) error
 //
 // Scopes: PlaylistReadPrivate.
 func (s *Spotify) GetPlaylistItems(id string, params ...Param) (*PlaylistTrackChunk, error) {
+	return s.GetPlaylistItemsCtx(context.Background(), id, params...)
+}
+
+// GetPlaylistItemsCtx is the same as GetPlaylistItems, but it sends the request with the given context.
+func (s *Spotify) GetPlaylistItemsCtx(
+	ctx context.Context,
+	id string,
+	params ...Param,
+) (*PlaylistTrackChunk, error) {
 	trackChunck := &PlaylistTrackChunk{}
-	err := s.Get(trackChunck, fmt.Sprintf("/playlists/%s/tracks", id), params...)
+	err := s.GetCtx(ctx, trackChunck, fmt.Sprintf("/playlists/%s/tracks", id), params...)
 	return trackChunck, err
 }
 
@@ -145,13 +173,23 @@ func (s *Spotify) UpdatePlaylistItems(
 	id string,
 	properties []Property,
 	params ...Param,
+) (*Snapshot, error) {
+	return s.UpdatePlaylistItemsCtx(context.Background(), id, properties, params...)
+}
+
+// UpdatePlaylistItemsCtx is the same as UpdatePlaylistItems, but it sends the request with the given context.
+func (s *Spotify) UpdatePlaylistItemsCtx(
+	ctx context.Context,
+	id string,
+	properties []Property,
+	params ...Param,
 ) (*Snapshot, error) {
 	snapshot := &Snapshot{}
 	body, err := createBodyFromProperties(properties)
 	if err != nil {
 		return nil, err
 	}
-	err = s.Put(snapshot, fmt.Sprintf("/playlists/%s/tracks", id), body, params...)
+	err = s.PutCtx(ctx, snapshot, fmt.Sprintf("/playlists/%s/tracks", id), body, params...)
 	return snapshot, err
 }
 
@@ -166,13 +204,23 @@ func (s *Spotify) AddItemsToPlaylist(
 	id string,
 	properties []Property,
 	params ...Param,
+) (*Snapshot, error) {
+	return s.AddItemsToPlaylistCtx(context.Background(), id, properties, params...)
+}
+
+// AddItemsToPlaylistCtx is the same as AddItemsToPlaylist, but it sends the request with the given context.
+func (s *Spotify) AddItemsToPlaylistCtx(
+	ctx context.Context,
+	id string,
+	properties []Property,
+	params ...Param,
 ) (*Snapshot, error) {
 	snapshot := &Snapshot{}
 	body, err := createBodyFromProperties(properties)
 	if err != nil {
 		return nil, err
 	}
-	err = s.Put(snapshot, fmt.Sprintf("/playlists/%s/tracks", id), body, params...)
+	err = s.PutCtx(ctx, snapshot, fmt.Sprintf("/playlists/%s/tracks", id), body, params...)
 	return snapshot, err
 }
 
@@ -182,12 +230,21 @@ func (s *Spotify) AddItemsToPlaylist(
 //
 // Scopes: ScopePlaylistModifyPublic, ScopePlaylistModifyPrivate.
 func (s *Spotify) RemovePlaylistItem(id string, properties []Property) (*Snapshot, error) {
+	return s.RemovePlaylistItemCtx(context.Background(), id, properties)
+}
+
+// RemovePlaylistItemCtx is the same as RemovePlaylistItem, but it sends the request with the given context.
+func (s *Spotify) RemovePlaylistItemCtx(
+	ctx context.Context,
+	id string,
+	properties []Property,
+) (*Snapshot, error) {
 	snapshot := &Snapshot{}
 	body, err := createBodyFromProperties(properties)
 	if err != nil {
 		return nil, err
 	}
-	err = s.Delete(snapshot, fmt.Sprintf("/playlists/%s/tracks", id), body)
+	err = s.DeleteCtx(ctx, snapshot, fmt.Sprintf("/playlists/%s/tracks", id), body)
 	return snapshot, err
 }
 
@@ -197,8 +254,16 @@ func (s *Spotify) RemovePlaylistItem(id string, properties []Property) (*Snapsho
 //
 // Scopes: ScopePlaylistReadPrivate.
 func (s *Spotify) GetCurrentUserPlaylists(params ...Param) (*SimplifiedPlaylistChunk, error) {
+	return s.GetCurrentUserPlaylistsCtx(context.Background(), params...)
+}
+
+// GetCurrentUserPlaylistsCtx is the same as GetCurrentUserPlaylists, but it sends the request with the given context.
+func (s *Spotify) GetCurrentUserPlaylistsCtx(
+	ctx context.Context,
+	params ...Param,
+) (*SimplifiedPlaylistChunk, error) {
 	playlistChunk := &SimplifiedPlaylistChunk{}
-	err := s.Get(playlistChunk, "/me/playlists")
+	err := s.GetCtx(ctx, playlistChunk, "/me/playlists")
 	return playlistChunk, err
 }
 
@@ -210,9 +275,18 @@ func (s *Spotify) GetCurrentUserPlaylists(params ...Param) (*SimplifiedPlaylistC
 func (s *Spotify) GetUserPlaylists(
 	userId string,
 	params ...Param,
+) (*SimplifiedPlaylistChunk, error) {
+	return s.GetUserPlaylistsCtx(context.Background(), userId, params...)
+}
+
+// GetUserPlaylistsCtx is the same as GetUserPlaylists, but it sends the request with the given context.
+func (s *Spotify) GetUserPlaylistsCtx(
+	ctx context.Context,
+	userId string,
+	params ...Param,
 ) (*SimplifiedPlaylistChunk, error) {
 	playlistChunk := &SimplifiedPlaylistChunk{}
-	err := s.Get(playlistChunk, fmt.Sprintf("/users/%s/playlists", userId))
+	err := s.GetCtx(ctx, playlistChunk, fmt.Sprintf("/users/%s/playlists", userId))
 	return playlistChunk, err
 }
 
@@ -223,19 +297,37 @@ func (s *Spotify) GetUserPlaylists(
 //
 // Scopes: ScopePlaylistModifyPublic, ScopePlaylistModifyPrivate.
 func (s *Spotify) CreatePlaylist(userId string, name Property, properties []Property) error {
+	return s.CreatePlaylistCtx(context.Background(), userId, name, properties)
+}
+
+// CreatePlaylistCtx is the same as CreatePlaylist, but it sends the request with the given context.
+func (s *Spotify) CreatePlaylistCtx(
+	ctx context.Context,
+	userId string,
+	name Property,
+	properties []Property,
+) error {
 	body, err := createBodyFromProperties(properties)
 	if err != nil {
 		return err
 	}
-	return s.Post(nil, fmt.Sprintf("/users/%s/playlists", userId), body)
+	return s.PostCtx(ctx, nil, fmt.Sprintf("/users/%s/playlists", userId), body)
 }
 
 // GetFeaturedPlaylists obtains a list of Spotify featured playlists (shown, for example, on a Spotify player's 'Browse' tab).
 //
 // Params: Locale, Limit, Offset.
 func (s *Spotify) GetFeaturedPlaylists(params ...Param) (*DescribedPlaylist, error) {
+	return s.GetFeaturedPlaylistsCtx(context.Background(), params...)
+}
+
+// GetFeaturedPlaylistsCtx is the same as GetFeaturedPlaylists, but it sends the request with the given context.
+func (s *Spotify) GetFeaturedPlaylistsCtx(
+	ctx context.Context,
+	params ...Param,
+) (*DescribedPlaylist, error) {
 	describedPlaylist := &DescribedPlaylist{}
-	err := s.Get(describedPlaylist, "/browse/featured-playlists", params...)
+	err := s.GetCtx(ctx, describedPlaylist, "/browse/featured-playlists", params...)
 	return describedPlaylist, err
 }
 
@@ -245,9 +337,19 @@ func (s *Spotify) GetFeaturedPlaylists(params ...Param) (*DescribedPlaylist, err
 func (s *Spotify) GetCategoryPlaylists(
 	categoryId string,
 	params ...Param,
+) (*DescribedPlaylist, error) {
+	return s.GetCategoryPlaylistsCtx(context.Background(), categoryId, params...)
+}
+
+// GetCategoryPlaylistsCtx is the same as GetCategoryPlaylists, but it sends the request with the given context.
+func (s *Spotify) GetCategoryPlaylistsCtx(
+	ctx context.Context,
+	categoryId string,
+	params ...Param,
 ) (*DescribedPlaylist, error) {
 	describedPlaylist := &DescribedPlaylist{}
-	err := s.Get(
+	err := s.GetCtx(
+		ctx,
 		describedPlaylist,
 		fmt.Sprintf("/browse/categories/%s/playlists", categoryId),
 		params...)
@@ -256,12 +358,22 @@ func (s *Spotify) GetCategoryPlaylists(
 
 // GetPlaylistCoverImage obtains the current image associated with a specific playlist.
 func (s *Spotify) GetPlaylistCoverImage(id string) ([]*Image, error) {
+	return s.GetPlaylistCoverImageCtx(context.Background(), id)
+}
+
+// GetPlaylistCoverImageCtx is the same as GetPlaylistCoverImage, but it sends the request with the given context.
+func (s *Spotify) GetPlaylistCoverImageCtx(ctx context.Context, id string) ([]*Image, error) {
 	image := []*Image{}
-	err := s.Get(&image, fmt.Sprintf("/playlists/%s/images", id))
+	err := s.GetCtx(ctx, &image, fmt.Sprintf("/playlists/%s/images", id))
 	return image, err
 }
 
 // AddCustomPlaylistCoverImage replaces the image used to represent a specific playlist.
 func (s *Spotify) AddCustomPlaylistCoverImage(id, data string) error {
-	return s.PutImage(nil, fmt.Sprintf("/playlists/%s/images", id), data)
+	return s.AddCustomPlaylistCoverImageCtx(context.Background(), id, data)
+}
+
+// AddCustomPlaylistCoverImageCtx is the same as AddCustomPlaylistCoverImage, but it sends the request with the given context.
+func (s *Spotify) AddCustomPlaylistCoverImageCtx(ctx context.Context, id, data string) error {
+	return s.PutImageCtx(ctx, nil, fmt.Sprintf("/playlists/%s/images", id), data)
 }
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Do sends the request with the given method to the endpoint, which has no dedicated method in the client yet,
+// such as the new or beta endpoint of the Spotify API.
+// The request passes through the same authorization, middleware and retries as the ones of the other methods,
+// and fails with the *Error if Spotify responds with the status outside the 2xx class.
+//
+// The endpoint is relative to the base url of the client, for example "/me/player/queue".
+// Absolute urls, such as the Next link of the chunk, are accepted too, as long as they point to the base url,
+// so the token is never sent anywhere else.
+// The body is sent as JSON if it is not empty. The response, if any, is decoded into the out object, unless it is nil.
+func (s *Spotify) Do(
+	ctx context.Context,
+	method, endpoint string,
+	body []byte,
+	out interface{},
+	params ...Param,
+) error {
+	endpoint, err := s.relativeEndpoint(endpoint)
+	if err != nil {
+		return err
+	}
+
+	headers := map[string]string{}
+	if len(body) > 0 {
+		headers["Content-Type"] = "application/json"
+	}
+	requestData := spotifyRequestData{
+		out,
+		strings.ToUpper(method),
+		endpoint,
+		params,
+		headers,
+		body,
+	}
+
+	return s.doRequest(ctx, requestData)
+}
+
+// relativeEndpoint turns the absolute url pointing to the base url of the client into the endpoint relative to it.
+// Relative endpoints are returned as is.
+func (s *Spotify) relativeEndpoint(endpoint string) (string, error) {
+	parsedUrl, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	if !parsedUrl.IsAbs() {
+		return endpoint, nil
+	}
+
+	base, err := url.Parse(s.url)
+	if err != nil {
+		return "", err
+	}
+	if parsedUrl.Scheme != base.Scheme || parsedUrl.Host != base.Host ||
+		!strings.HasPrefix(parsedUrl.Path, base.Path+"/") {
+		return "", fmt.Errorf("spotify request error: %s is outside of %s", endpoint, s.url)
+	}
+
+	parsedUrl.Path = strings.TrimPrefix(parsedUrl.Path, base.Path)
+	parsedUrl.RawPath = ""
+	return parsedUrl.RequestURI(), nil
+}
+
+// GetAs sends the GET request to the endpoint, decoding the response into the new object of type T.
+// It is the typed counterpart of Do, intended for the endpoints without the dedicated method.
+func GetAs[T any](ctx context.Context, s *Spotify, endpoint string, params ...Param) (*T, error) {
+	return doAs[T](ctx, s, http.MethodGet, endpoint, nil, params...)
+}
+
+// PostAs sends the POST request with the given JSON body to the endpoint, decoding the response into the new object of type T.
+// It is the typed counterpart of Do, intended for the endpoints without the dedicated method.
+func PostAs[T any](
+	ctx context.Context,
+	s *Spotify,
+	endpoint string,
+	body []byte,
+	params ...Param,
+) (*T, error) {
+	return doAs[T](ctx, s, http.MethodPost, endpoint, body, params...)
+}
+
+// PutAs sends the PUT request with the given JSON body to the endpoint, decoding the response into the new object of type T.
+// It is the typed counterpart of Do, intended for the endpoints without the dedicated method.
+func PutAs[T any](
+	ctx context.Context,
+	s *Spotify,
+	endpoint string,
+	body []byte,
+	params ...Param,
+) (*T, error) {
+	return doAs[T](ctx, s, http.MethodPut, endpoint, body, params...)
+}
+
+// DeleteAs sends the DELETE request with the given JSON body to the endpoint, decoding the response into the new object of type T.
+// It is the typed counterpart of Do, intended for the endpoints without the dedicated method.
+func DeleteAs[T any](
+	ctx context.Context,
+	s *Spotify,
+	endpoint string,
+	body []byte,
+	params ...Param,
+) (*T, error) {
+	return doAs[T](ctx, s, http.MethodDelete, endpoint, body, params...)
+}
+
+// doAs sends the request with Do, decoding the response into the new object of type T.
+func doAs[T any](
+	ctx context.Context,
+	s *Spotify,
+	method, endpoint string,
+	body []byte,
+	params ...Param,
+) (*T, error) {
+	out := new(T)
+	err := s.Do(ctx, method, endpoint, body, out, params...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+)
+
+type testBetaObject struct {
+	Id    string `json:"id"`
+	Label string `json:"label"`
+}
+
+func TestDo(t *testing.T) {
+	var method, uri, contentType, body string
+	server, spotify := testServer(func(w http.ResponseWriter, r *http.Request) {
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			panic(err)
+		}
+		method, uri, contentType, body = r.Method, r.URL.RequestURI(), r.Header.Get("Content-Type"), string(data)
+		err = writeResponse(w, []byte(`{"id":"`+testId+`","label":"beta"}`))
+		if err != nil {
+			panic(err)
+		}
+	})
+	defer server.Close()
+
+	out := &testBetaObject{}
+	err := spotify.Do(
+		context.Background(),
+		"post",
+		"/beta/objects?kind=test",
+		[]byte(`{"name":"test"}`),
+		out,
+		QueryParam("flag", "on"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if method != http.MethodPost || uri != "/beta/objects?flag=on&kind=test" {
+		t.Errorf("Unexpected request: %s %s", method, uri)
+	}
+	if contentType != "application/json" || body != `{"name":"test"}` {
+		t.Errorf("Unexpected body: %s %s", contentType, body)
+	}
+	if out.Id != testId || out.Label != "beta" {
+		t.Errorf("Unexpected response: %+v", out)
+	}
+
+	object, err := GetAs[testBetaObject](context.Background(), spotify, server.URL+"/beta/objects/"+testId, Market("ES"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if method != http.MethodGet || uri != "/beta/objects/"+testId+"?market=ES" {
+		t.Errorf("Unexpected request: %s %s", method, uri)
+	}
+	if object.Id != testId {
+		t.Errorf("Expected %s, got %s", testId, object.Id)
+	}
+}
+
+func TestDoOutsideBaseURL(t *testing.T) {
+	server, spotify := testServer(testHandler(), WithBaseURL("https://api.spotify.com/v1"))
+	defer server.Close()
+
+	for _, endpoint := range []string{
+		"https://example.com/v1/me",
+		"http://api.spotify.com/v1/me",
+		"https://api.spotify.com/v10/me",
+	} {
+		err := spotify.Do(context.Background(), http.MethodGet, endpoint, nil, nil)
+		if err == nil {
+			t.Errorf("Expected %s to be rejected", endpoint)
+		}
+	}
+
+	endpoint, err := spotify.relativeEndpoint("https://api.spotify.com/v1/me/tracks?offset=20&limit=20")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if endpoint != "/me/tracks?offset=20&limit=20" {
+		t.Errorf("Unexpected endpoint: %s", endpoint)
+	}
+}
+
+func TestDoEscapedEndpoint(t *testing.T) {
+	var path, query string
+	server, spotify := testServer(func(w http.ResponseWriter, r *http.Request) {
+		path, query = r.URL.EscapedPath(), r.URL.RawQuery
+		if err := writeResponse(w, []byte(`{"id":"we?ird#name"}`)); err != nil {
+			panic(err)
+		}
+	})
+	defer server.Close()
+
+	user, err := GetAs[User](context.Background(), spotify, "/users/we%3Fird%23name", Market("ES"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path != "/users/we%3Fird%23name" || query != "market=ES" {
+		t.Errorf("Unexpected request: %s?%s", path, query)
+	}
+	if user.Id != "we?ird#name" {
+		t.Errorf("Unexpected user: %s", user.Id)
+	}
+}
+
+func TestGetAsError(t *testing.T) {
+	server, spotify := testServer(
+		testErrorHandler(http.StatusNotFound, nil, `{"error":{"status":404,"message":"Not found."}}`),
+	)
+	defer server.Close()
+
+	object, err := GetAs[testBetaObject](context.Background(), spotify, "/beta/objects/"+testId)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected %v, got %v", ErrNotFound, err)
+	}
+	if object != nil {
+		t.Errorf("Expected nil object, got %+v", object)
+	}
+}
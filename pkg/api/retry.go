@@ -0,0 +1,111 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy describes how the Spotify client retries the requests,
+// that failed because of the rate limit (429) or a temporary server error (500, 502, 503).
+type RetryPolicy struct {
+	// The maximum number of retries after the first attempt. Zero disables retries.
+	MaxRetries int
+	// The delay before the first retry. It is doubled on every following retry.
+	BaseDelay time.Duration
+	// The upper bound of a single delay. If the Retry-After header asks to wait longer,
+	// the request is not retried and the error is returned to the caller.
+	MaxDelay time.Duration
+	// Whether requests that are not idempotent (POST, and the player commands like skipping to the next track)
+	// are retried as well.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns the policy used by the clients created with NewSpotifyClient.
+// It retries idempotent requests up to 3 times, starting with a half-second delay.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: 3,
+		BaseDelay:  500 * time.Millisecond,
+		MaxDelay:   30 * time.Second,
+	}
+}
+
+// backoff decides whether the request, which finished with the given error, should be retried,
+// and how long to wait before the next attempt.
+// Attempts are counted from zero, so the first retry follows the attempt 0.
+func (p *RetryPolicy) backoff(method, endpoint string, attempt int, err error) (time.Duration, bool) {
+	if p == nil || err == nil || attempt >= p.MaxRetries {
+		return 0, false
+	}
+	if !p.RetryNonIdempotent && !isIdempotent(method, endpoint) {
+		return 0, false
+	}
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		return 0, false
+	}
+
+	switch apiErr.Status {
+	case http.StatusTooManyRequests:
+		if apiErr.RetryAfter > 0 {
+			return apiErr.RetryAfter, p.MaxDelay <= 0 || apiErr.RetryAfter <= p.MaxDelay
+		}
+		return p.exponential(attempt), true
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable:
+		return p.exponential(attempt), true
+	}
+	return 0, false
+}
+
+// exponential calculates the jittered exponential delay for the given attempt.
+// The delay is picked randomly from the upper half of the exponential step,
+// so the clients that failed together do not retry together.
+func (p *RetryPolicy) exponential(attempt int) time.Duration {
+	delay := p.BaseDelay << attempt
+	if delay <= 0 || (p.MaxDelay > 0 && delay > p.MaxDelay) {
+		delay = p.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(delay-half)+1))
+}
+
+// nonIdempotentEndpoints are the endpoints, which are sent with the PUT method,
+// but have the effect every time they are sent: repeating them skips or queues the track once more.
+var nonIdempotentEndpoints = map[string]bool{
+	"/me/player/next":     true,
+	"/me/player/previous": true,
+	"/me/player/queue":    true,
+}
+
+// isIdempotent checks if repeating the request with the given method to the endpoint has the same effect as sending it once.
+func isIdempotent(method, endpoint string) bool {
+	if nonIdempotentEndpoints[endpoint] {
+		return false
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// wait blocks for the given duration, or until the context is done.
+func wait(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
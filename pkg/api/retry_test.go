@@ -0,0 +1,189 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testFlakyHandler(calls *int32, failures int32, status int, body []byte) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(calls, 1) <= failures {
+			w.WriteHeader(status)
+			return
+		}
+
+		err := writeResponse(w, body)
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+func testRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: 3,
+		BaseDelay:  time.Millisecond,
+		MaxDelay:   10 * time.Millisecond,
+	}
+}
+
+func TestRetryServerErrors(t *testing.T) {
+	for _, status := range []int{
+		http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+	} {
+		var calls int32
+		server, spotify := testServer(
+			testFlakyHandler(&calls, 2, status, []byte(`{"id":"`+testId+`"}`)),
+			WithRetryPolicy(testRetryPolicy()),
+		)
+
+		track, err := spotify.GetTrack(testId)
+		server.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if track.Id != testId {
+			t.Errorf("Expected %s, got %s", testId, track.Id)
+		}
+		if calls != 3 {
+			t.Errorf("Expected 3 calls for status %d, got %d", status, calls)
+		}
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	var calls int32
+	server, spotify := testServer(
+		testFlakyHandler(&calls, 10, http.StatusServiceUnavailable, nil),
+		WithRetryPolicy(testRetryPolicy()),
+	)
+	defer server.Close()
+
+	_, err := spotify.GetTrack(testId)
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusServiceUnavailable {
+		t.Fatalf("Expected *Error with status %d, got %v", http.StatusServiceUnavailable, err)
+	}
+	if calls != 4 {
+		t.Errorf("Expected 4 calls, got %d", calls)
+	}
+}
+
+func TestRetrySkipsPlayerCommands(t *testing.T) {
+	for name, command := range map[string]func(spotify *Spotify) error{
+		"next":     func(spotify *Spotify) error { return spotify.SkipToNext() },
+		"previous": func(spotify *Spotify) error { return spotify.SkipToPrevious() },
+		"queue":    func(spotify *Spotify) error { return spotify.AddItemToPlaybackQueue("spotify:track:" + testId) },
+	} {
+		var calls int32
+		server, spotify := testServer(
+			testFlakyHandler(&calls, 1, http.StatusServiceUnavailable, nil),
+			WithRetryPolicy(testRetryPolicy()),
+		)
+		err := command(spotify)
+		server.Close()
+		if err == nil {
+			t.Errorf("Expected %s to fail, got nil", name)
+		}
+		if calls != 1 {
+			t.Errorf("Expected %s to be sent once, got %d calls", name, calls)
+		}
+	}
+
+	var calls int32
+	server, spotify := testServer(
+		testFlakyHandler(&calls, 1, http.StatusServiceUnavailable, nil),
+		WithRetryPolicy(testRetryPolicy()),
+	)
+	defer server.Close()
+	if err := spotify.SeekToPosition(1000); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected idempotent player command to be retried, got %d calls", calls)
+	}
+}
+
+func TestRetrySkipsNonIdempotent(t *testing.T) {
+	var calls int32
+	server, spotify := testServer(
+		testFlakyHandler(&calls, 1, http.StatusServiceUnavailable, nil),
+		WithRetryPolicy(testRetryPolicy()),
+	)
+	defer server.Close()
+
+	err := spotify.CreatePlaylist(testId, Name("test"), []Property{})
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 call, got %d", calls)
+	}
+
+	policy := testRetryPolicy()
+	policy.RetryNonIdempotent = true
+	spotify = NewSpotifyClient(
+		context.Background(),
+		WithBaseURL(server.URL),
+		WithRetryPolicy(policy),
+	)
+
+	err = spotify.CreatePlaylist(testId, Name("test"), []Property{})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRetryRespectsContext(t *testing.T) {
+	var calls int32
+	server, spotify := testServer(
+		testFlakyHandler(&calls, 10, http.StatusServiceUnavailable, nil),
+		WithRetryPolicy(RetryPolicy{MaxRetries: 3, BaseDelay: time.Hour}),
+	)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := spotify.GetTrackCtx(ctx, testId)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected %v, got %v", context.DeadlineExceeded, err)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	policy := testRetryPolicy()
+	rateLimited := &Error{Status: http.StatusTooManyRequests, RetryAfter: 5 * time.Millisecond}
+
+	delay, ok := policy.backoff(http.MethodGet, "/tracks", 0, rateLimited)
+	if !ok || delay != rateLimited.RetryAfter {
+		t.Errorf("Expected Retry-After delay %v, got %v (%t)", rateLimited.RetryAfter, delay, ok)
+	}
+
+	rateLimited.RetryAfter = time.Minute
+	if _, ok := policy.backoff(http.MethodGet, "/tracks", 0, rateLimited); ok {
+		t.Errorf("Expected no retry when Retry-After exceeds the maximum delay")
+	}
+
+	if _, ok := policy.backoff(http.MethodGet, "/tracks", 0, &Error{Status: http.StatusNotFound}); ok {
+		t.Errorf("Expected no retry for status %d", http.StatusNotFound)
+	}
+
+	if _, ok := policy.backoff(http.MethodGet, "/tracks", 3, &Error{Status: http.StatusBadGateway}); ok {
+		t.Errorf("Expected no retry after the maximum number of retries")
+	}
+
+	for attempt := 0; attempt < 10; attempt++ {
+		delay := policy.exponential(attempt)
+		if delay > policy.MaxDelay {
+			t.Errorf("Expected delay up to %v, got %v", policy.MaxDelay, delay)
+		}
+	}
+}
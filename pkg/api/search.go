@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"strings"
 )
@@ -22,8 +23,19 @@ type SearchResult struct {
 //
 // Params: Market, Limit, Offset, IncludeExternal.
 func (s *Spotify) Search(q string, types []string, params ...Param) (*SearchResult, error) {
+	return s.SearchCtx(context.Background(), q, types, params...)
+}
+
+// SearchCtx is the same as Search, but it sends the request with the given context.
+func (s *Spotify) SearchCtx(
+	ctx context.Context,
+	q string,
+	types []string,
+	params ...Param,
+) (*SearchResult, error) {
 	result := &SearchResult{}
-	err := s.Get(
+	err := s.GetCtx(
+		ctx,
 		result,
 		fmt.Sprintf("/search?q=%s&type=%s", q, strings.Join(types, ",")),
 		params...)
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"strings"
 )
@@ -59,8 +60,13 @@ type FullShow struct {
 //
 // Scopes: ScopeUserReadPlaybackPosition.
 func (s *Spotify) GetShow(id string, params ...Param) (*FullShow, error) {
+	return s.GetShowCtx(context.Background(), id, params...)
+}
+
+// GetShowCtx is the same as GetShow, but it sends the request with the given context.
+func (s *Spotify) GetShowCtx(ctx context.Context, id string, params ...Param) (*FullShow, error) {
 	show := &FullShow{}
-	err := s.Get(show, fmt.Sprintf("/shows/%s", id), params...)
+	err := s.GetCtx(ctx, show, fmt.Sprintf("/shows/%s", id), params...)
 	return show, err
 }
 
@@ -68,11 +74,22 @@ func (s *Spotify) GetShow(id string, params ...Param) (*FullShow, error) {
 //
 // Params: Market.
 func (s *Spotify) GetShows(ids []string, params ...Param) ([]*FullShow, error) {
-	var w struct {
-		Shows []*FullShow `json:"shows"`
-	}
-	err := s.Get(&w, fmt.Sprintf("/shows?ids=%s", strings.Join(ids, ",")), params...)
-	return w.Shows, err
+	return s.GetShowsCtx(context.Background(), ids, params...)
+}
+
+// GetShowsCtx is the same as GetShows, but it sends the request with the given context.
+func (s *Spotify) GetShowsCtx(
+	ctx context.Context,
+	ids []string,
+	params ...Param,
+) ([]*FullShow, error) {
+	return batchIds(ctx, s, ids, maxShowIds, func(ctx context.Context, ids []string) ([]*FullShow, error) {
+		var w struct {
+			Shows []*FullShow `json:"shows"`
+		}
+		err := s.GetCtx(ctx, &w, fmt.Sprintf("/shows?ids=%s", strings.Join(ids, ",")), params...)
+		return w.Shows, err
+	})
 }
 
 // GetShowEpisodes obtains Spotify catalog information about an show’s episodes.
@@ -82,8 +99,17 @@ func (s *Spotify) GetShows(ids []string, params ...Param) ([]*FullShow, error) {
 //
 // Scopes: ScopeUserReadPlaybackPosition.
 func (s *Spotify) GetShowEpisodes(id string, params ...Param) (*SimplifiedEpisodeChunk, error) {
+	return s.GetShowEpisodesCtx(context.Background(), id, params...)
+}
+
+// GetShowEpisodesCtx is the same as GetShowEpisodes, but it sends the request with the given context.
+func (s *Spotify) GetShowEpisodesCtx(
+	ctx context.Context,
+	id string,
+	params ...Param,
+) (*SimplifiedEpisodeChunk, error) {
 	episodeChunk := &SimplifiedEpisodeChunk{}
-	err := s.Get(episodeChunk, fmt.Sprintf("/shows/%s/episodes", id), params...)
+	err := s.GetCtx(ctx, episodeChunk, fmt.Sprintf("/shows/%s/episodes", id), params...)
 	return episodeChunk, err
 }
 
@@ -94,8 +120,16 @@ func (s *Spotify) GetShowEpisodes(id string, params ...Param) (*SimplifiedEpisod
 //
 // Scopes: ScopeUserLibraryRead
 func (s *Spotify) GetUserSavedShows(params ...Param) (*SimplifiedShowChunk, error) {
+	return s.GetUserSavedShowsCtx(context.Background(), params...)
+}
+
+// GetUserSavedShowsCtx is the same as GetUserSavedShows, but it sends the request with the given context.
+func (s *Spotify) GetUserSavedShowsCtx(
+	ctx context.Context,
+	params ...Param,
+) (*SimplifiedShowChunk, error) {
 	showChunk := &SimplifiedShowChunk{}
-	err := s.Get(showChunk, "/me/shows", params...)
+	err := s.GetCtx(ctx, showChunk, "/me/shows", params...)
 	return showChunk, err
 }
 
@@ -103,7 +137,14 @@ func (s *Spotify) GetUserSavedShows(params ...Param) (*SimplifiedShowChunk, erro
 //
 // Scopes: ScopeUserLibraryModify.
 func (s *Spotify) SaveShowsForCurrentUser(ids []string) error {
-	return s.Put(nil, fmt.Sprintf("/me/shows?ids=%s", strings.Join(ids, ",")), []byte{})
+	return s.SaveShowsForCurrentUserCtx(context.Background(), ids)
+}
+
+// SaveShowsForCurrentUserCtx is the same as SaveShowsForCurrentUser, but it sends the request with the given context.
+func (s *Spotify) SaveShowsForCurrentUserCtx(ctx context.Context, ids []string) error {
+	return batchEach(ctx, s, ids, maxSavedShowIds, func(ctx context.Context, ids []string) error {
+		return s.PutCtx(ctx, nil, fmt.Sprintf("/me/shows?ids=%s", strings.Join(ids, ",")), []byte{})
+	})
 }
 
 // RemoveUserSavedShows removes one or more shows from current Spotify user's library.
@@ -112,18 +153,41 @@ func (s *Spotify) SaveShowsForCurrentUser(ids []string) error {
 //
 // Scopes: ScopeUserLibraryModify.
 func (s *Spotify) RemoveUserSavedShows(ids []string, params ...Param) error {
-	return s.Delete(
-		nil,
-		fmt.Sprintf("/me/shows?ids=%s", strings.Join(ids, ",")),
-		[]byte{},
-		params...)
+	return s.RemoveUserSavedShowsCtx(context.Background(), ids, params...)
+}
+
+// RemoveUserSavedShowsCtx is the same as RemoveUserSavedShows, but it sends the request with the given context.
+func (s *Spotify) RemoveUserSavedShowsCtx(
+	ctx context.Context,
+	ids []string,
+	params ...Param,
+) error {
+	return batchEach(ctx, s, ids, maxSavedShowIds, func(ctx context.Context, ids []string) error {
+		return s.DeleteCtx(
+			ctx,
+			nil,
+			fmt.Sprintf("/me/shows?ids=%s", strings.Join(ids, ",")),
+			[]byte{},
+			params...)
+	})
 }
 
 // CheckUserSavedShows checks if one or more shows is already saved in the current Spotify user's library.
 //
 // Scopes: ScopeUserLibraryRead.
 func (s *Spotify) CheckUserSavedShows(ids []string) ([]bool, error) {
-	containmentInfo := []bool{}
-	err := s.Get(&containmentInfo, fmt.Sprintf("/me/shows/contains?ids=%s", strings.Join(ids, ",")))
-	return containmentInfo, err
+	return s.CheckUserSavedShowsCtx(context.Background(), ids)
+}
+
+// CheckUserSavedShowsCtx is the same as CheckUserSavedShows, but it sends the request with the given context.
+func (s *Spotify) CheckUserSavedShowsCtx(ctx context.Context, ids []string) ([]bool, error) {
+	return batchIds(ctx, s, ids, maxSavedShowIds, func(ctx context.Context, ids []string) ([]bool, error) {
+		containmentInfo := []bool{}
+		err := s.GetCtx(
+			ctx,
+			&containmentInfo,
+			fmt.Sprintf("/me/shows/contains?ids=%s", strings.Join(ids, ",")),
+		)
+		return containmentInfo, err
+	})
 }
@@ -0,0 +1,121 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// flightGroup tracks the GET requests currently in flight, so the identical ones are sent only once.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+// flightCall is the request in flight, shared by all the callers waiting for it.
+type flightCall struct {
+	// Closed once the response is received.
+	done chan struct{}
+	// The number of callers still waiting for the response.
+	waiters int
+	// Cancels the shared request once no caller waits for it.
+	cancel context.CancelFunc
+
+	// The shared response, or nil if the request failed.
+	res  *Response
+	body []byte
+	err  error
+}
+
+// response creates the independent copy of the shared response, so every caller can read and decode it on its own.
+func (c *flightCall) response() *Response {
+	if c.res == nil {
+		return nil
+	}
+	return &Response{
+		StatusCode: c.res.StatusCode,
+		Header:     c.res.Header.Clone(),
+		Body:       io.NopCloser(bytes.NewReader(c.body)),
+	}
+}
+
+// flightKey returns the key the identical requests share, or false if the request should not be coalesced.
+// Only GET requests are coalesced, and only if they are authorized with the same token,
+// so the responses are never shared between users.
+func (s *Spotify) flightKey(req *Request) (string, bool) {
+	if req.Method != http.MethodGet {
+		return "", false
+	}
+
+	key := req.Endpoint + "?" + req.Params.Encode()
+	if token, ok := s.tokenKey(); ok {
+		key += "#" + token
+	}
+	return key, true
+}
+
+// singleflightMiddleware coalesces the identical GET requests made at the same time into the single request.
+// The body of the response is read once and handed to every caller as the separate copy,
+// so the decoded results are not shared between them.
+// The shared request is canceled only when every caller waiting for it is gone.
+func (s *Spotify) singleflightMiddleware(next Handler) Handler {
+	return func(ctx context.Context, req *Request) (*Response, error) {
+		key, ok := s.flightKey(req)
+		if !ok {
+			return next(ctx, req)
+		}
+
+		g := s.flights
+		g.mu.Lock()
+		call, ok := g.calls[key]
+		if ok {
+			call.waiters++
+		} else {
+			sharedCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+			call = &flightCall{done: make(chan struct{}), waiters: 1, cancel: cancel}
+			g.calls[key] = call
+			go g.do(sharedCtx, key, call, next, req)
+		}
+		g.mu.Unlock()
+
+		select {
+		case <-call.done:
+			if call.err != nil {
+				return nil, call.err
+			}
+			return call.response(), nil
+		case <-ctx.Done():
+			g.mu.Lock()
+			call.waiters--
+			if call.waiters == 0 {
+				call.cancel()
+				if g.calls[key] == call {
+					delete(g.calls, key)
+				}
+			}
+			g.mu.Unlock()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// do sends the shared request and stores its response in the call.
+func (g *flightGroup) do(ctx context.Context, key string, call *flightCall, next Handler, req *Request) {
+	defer call.cancel()
+
+	res, err := next(ctx, req)
+	if err == nil && res != nil {
+		call.res = res
+		call.body, err = res.readBody()
+	}
+	call.err = err
+
+	g.mu.Lock()
+	if g.calls[key] == call {
+		delete(g.calls, key)
+	}
+	g.mu.Unlock()
+	close(call.done)
+}
@@ -0,0 +1,121 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testBlockingHandler(calls *int32, release <-chan struct{}, body []byte) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+		<-release
+		err := writeResponse(w, body)
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+func waitFlightWaiters(t *testing.T, spotify *Spotify, waiters int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for {
+		spotify.flights.mu.Lock()
+		current := 0
+		for _, call := range spotify.flights.calls {
+			current += call.waiters
+		}
+		spotify.flights.mu.Unlock()
+		if current == waiters {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected %d waiters, got %d", waiters, current)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestSingleflight(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	server, spotify := testServer(
+		testBlockingHandler(&calls, release, []byte(`{"id":"`+testId+`"}`)),
+		WithSingleflight(),
+	)
+	defer server.Close()
+
+	const callers = 5
+	albums := make([]*FullAlbum, callers)
+	var wg sync.WaitGroup
+	for i := range albums {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			album, err := spotify.GetAlbum(testId)
+			if err != nil {
+				t.Error(err)
+			}
+			albums[i] = album
+		}(i)
+	}
+	waitFlightWaiters(t, spotify, callers)
+	close(release)
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("Expected 1 call, got %d", calls)
+	}
+	for i, album := range albums {
+		if album == nil || album.Id != testId {
+			t.Fatalf("Expected %s, got %+v", testId, album)
+		}
+		for _, other := range albums[:i] {
+			if album == other {
+				t.Errorf("Expected every caller to receive its own copy")
+			}
+		}
+	}
+}
+
+func TestSingleflightCanceledCaller(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	server, spotify := testServer(
+		testBlockingHandler(&calls, release, []byte(`{"id":"`+testId+`"}`)),
+		WithSingleflight(),
+	)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	canceled := make(chan error)
+	go func() {
+		_, err := spotify.GetAlbumCtx(ctx, testId)
+		canceled <- err
+	}()
+	waitFlightWaiters(t, spotify, 1)
+
+	done := make(chan error)
+	go func() {
+		_, err := spotify.GetAlbum(testId)
+		done <- err
+	}()
+	waitFlightWaiters(t, spotify, 2)
+
+	cancel()
+	if err := <-canceled; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	close(release)
+	if err := <-done; err != nil {
+		t.Errorf("Expected remaining caller to succeed, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 call, got %d", calls)
+	}
+}
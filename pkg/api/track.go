@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"strings"
 )
@@ -378,8 +379,13 @@ type AudioAnalysis struct {
 //
 // Params: Market.
 func (s *Spotify) GetTrack(id string, params ...Param) (*FullTrack, error) {
+	return s.GetTrackCtx(context.Background(), id, params...)
+}
+
+// GetTrackCtx is the same as GetTrack, but it sends the request with the given context.
+func (s *Spotify) GetTrackCtx(ctx context.Context, id string, params ...Param) (*FullTrack, error) {
 	track := &FullTrack{}
-	err := s.Get(track, fmt.Sprintf("/tracks/%s", id), params...)
+	err := s.GetCtx(ctx, track, fmt.Sprintf("/tracks/%s", id), params...)
 	return track, err
 }
 
@@ -387,11 +393,22 @@ func (s *Spotify) GetTrack(id string, params ...Param) (*FullTrack, error) {
 //
 // Params: Market.
 func (s *Spotify) GetTracks(ids []string, params ...Param) ([]*FullTrack, error) {
-	var w struct {
-		Tracks []*FullTrack `json:"tracks"`
-	}
-	err := s.Get(&w, fmt.Sprintf("/tracks?ids=%s", strings.Join(ids, ",")), params...)
-	return w.Tracks, err
+	return s.GetTracksCtx(context.Background(), ids, params...)
+}
+
+// GetTracksCtx is the same as GetTracks, but it sends the request with the given context.
+func (s *Spotify) GetTracksCtx(
+	ctx context.Context,
+	ids []string,
+	params ...Param,
+) ([]*FullTrack, error) {
+	return batchIds(ctx, s, ids, maxTrackIds, func(ctx context.Context, ids []string) ([]*FullTrack, error) {
+		var w struct {
+			Tracks []*FullTrack `json:"tracks"`
+		}
+		err := s.GetCtx(ctx, &w, fmt.Sprintf("/tracks?ids=%s", strings.Join(ids, ",")), params...)
+		return w.Tracks, err
+	})
 }
 
 // GetUserSavedTracks obtains a list of the songs saved in the current Spotify user's 'Your Music' library.
@@ -400,8 +417,16 @@ func (s *Spotify) GetTracks(ids []string, params ...Param) ([]*FullTrack, error)
 //
 // Scopes: ScopeUserLibraryRead.
 func (s *Spotify) GetUserSavedTracks(params ...Param) (*SavedTrackChunk, error) {
+	return s.GetUserSavedTracksCtx(context.Background(), params...)
+}
+
+// GetUserSavedTracksCtx is the same as GetUserSavedTracks, but it sends the request with the given context.
+func (s *Spotify) GetUserSavedTracksCtx(
+	ctx context.Context,
+	params ...Param,
+) (*SavedTrackChunk, error) {
 	trackChunk := &SavedTrackChunk{}
-	err := s.Get(trackChunk, "/me/tracks", params...)
+	err := s.GetCtx(ctx, trackChunk, "/me/tracks", params...)
 	return trackChunk, err
 }
 
@@ -409,49 +434,91 @@ func (s *Spotify) GetUserSavedTracks(params ...Param) (*SavedTrackChunk, error)
 //
 // Scopes: ScopeUserLibraryModify.
 func (s *Spotify) SaveTracksForCurrentUser(ids []string) error {
-	return s.Put(nil, fmt.Sprintf("/me/tracks?ids=%s", strings.Join(ids, ",")), []byte{})
+	return s.SaveTracksForCurrentUserCtx(context.Background(), ids)
+}
+
+// SaveTracksForCurrentUserCtx is the same as SaveTracksForCurrentUser, but it sends the request with the given context.
+func (s *Spotify) SaveTracksForCurrentUserCtx(ctx context.Context, ids []string) error {
+	return batchEach(ctx, s, ids, maxSavedTrackIds, func(ctx context.Context, ids []string) error {
+		return s.PutCtx(ctx, nil, fmt.Sprintf("/me/tracks?ids=%s", strings.Join(ids, ",")), []byte{})
+	})
 }
 
 // RemoveUserSavedTracks removes one or more tracks from the current user's 'Your Music' library.
 //
 // Scopes: ScopeUserLibraryModify.
 func (s *Spotify) RemoveUserSavedTracks(ids []string) error {
-	return s.Delete(nil, fmt.Sprintf("/me/tracks?ids=%s", strings.Join(ids, ",")), []byte{})
+	return s.RemoveUserSavedTracksCtx(context.Background(), ids)
+}
+
+// RemoveUserSavedTracksCtx is the same as RemoveUserSavedTracks, but it sends the request with the given context.
+func (s *Spotify) RemoveUserSavedTracksCtx(ctx context.Context, ids []string) error {
+	return batchEach(ctx, s, ids, maxSavedTrackIds, func(ctx context.Context, ids []string) error {
+		return s.DeleteCtx(ctx, nil, fmt.Sprintf("/me/tracks?ids=%s", strings.Join(ids, ",")), []byte{})
+	})
 }
 
 // CheckUserSavedTracks checks if one or more tracks is already saved in the current Spotify user's 'Your Music' library.
 //
 // Scopes: ScopeUserLibraryRead.
 func (s *Spotify) CheckUserSavedTracks(ids []string) ([]bool, error) {
-	containmentInfo := []bool{}
-	err := s.Get(
-		&containmentInfo,
-		fmt.Sprintf("/me/tracks/contains?ids=%s", strings.Join(ids, ",")),
-	)
-	return containmentInfo, err
+	return s.CheckUserSavedTracksCtx(context.Background(), ids)
+}
+
+// CheckUserSavedTracksCtx is the same as CheckUserSavedTracks, but it sends the request with the given context.
+func (s *Spotify) CheckUserSavedTracksCtx(ctx context.Context, ids []string) ([]bool, error) {
+	return batchIds(ctx, s, ids, maxSavedTrackIds, func(ctx context.Context, ids []string) ([]bool, error) {
+		containmentInfo := []bool{}
+		err := s.GetCtx(
+			ctx,
+			&containmentInfo,
+			fmt.Sprintf("/me/tracks/contains?ids=%s", strings.Join(ids, ",")),
+		)
+		return containmentInfo, err
+	})
 }
 
 // GetTracksAudioFeatures obtains audio features for multiple tracks based on their Spotify IDs.
 func (s *Spotify) GetTracksAudioFeatures(ids []string) ([]*AudioFeature, error) {
-	var w struct {
-		AudioFeatures []*AudioFeature `json:"audio_features"`
-	}
-	err := s.Get(&w, fmt.Sprintf("/audio-features?ids=%s", strings.Join(ids, ",")))
-	return w.AudioFeatures, err
+	return s.GetTracksAudioFeaturesCtx(context.Background(), ids)
+}
+
+// GetTracksAudioFeaturesCtx is the same as GetTracksAudioFeatures, but it sends the request with the given context.
+func (s *Spotify) GetTracksAudioFeaturesCtx(
+	ctx context.Context,
+	ids []string,
+) ([]*AudioFeature, error) {
+	return batchIds(ctx, s, ids, maxAudioFeatureIds, func(ctx context.Context, ids []string) ([]*AudioFeature, error) {
+		var w struct {
+			AudioFeatures []*AudioFeature `json:"audio_features"`
+		}
+		err := s.GetCtx(ctx, &w, fmt.Sprintf("/audio-features?ids=%s", strings.Join(ids, ",")))
+		return w.AudioFeatures, err
+	})
 }
 
 // GetTrackAudioFeatures obtains audio feature information for a single track identified by its unique Spotify ID.
 func (s *Spotify) GetTrackAudioFeatures(id string) (*AudioFeature, error) {
+	return s.GetTrackAudioFeaturesCtx(context.Background(), id)
+}
+
+// GetTrackAudioFeaturesCtx is the same as GetTrackAudioFeatures, but it sends the request with the given context.
+func (s *Spotify) GetTrackAudioFeaturesCtx(ctx context.Context, id string) (*AudioFeature, error) {
 	audioFeature := &AudioFeature{}
-	err := s.Get(audioFeature, fmt.Sprintf("/audio-features/%s", id))
+	err := s.GetCtx(ctx, audioFeature, fmt.Sprintf("/audio-features/%s", id))
 	return audioFeature, err
 }
 
 // GetTrackAudioAnalysis obtains a low-level audio analysis for a track in the Spotify catalog.
 // The audio analysis describes the track’s structure and musical content, including rhythm, pitch, and timbre.
 func (s *Spotify) GetTrackAudioAnalysis(id string) (*AudioAnalysis, error) {
+	return s.GetTrackAudioAnalysisCtx(context.Background(), id)
+}
+
+// GetTrackAudioAnalysisCtx is the same as GetTrackAudioAnalysis, but it sends the request with the given context.
+func (s *Spotify) GetTrackAudioAnalysisCtx(ctx context.Context, id string) (*AudioAnalysis, error) {
 	audioAnalysis := &AudioAnalysis{}
-	err := s.Get(audioAnalysis, fmt.Sprintf("/audio-analysis/%s", id))
+	err := s.GetCtx(ctx, audioAnalysis, fmt.Sprintf("/audio-analysis/%s", id))
 	return audioAnalysis, err
 }
 
@@ -475,7 +542,15 @@ func (s *Spotify) GetTrackAudioAnalysis(id string) (*AudioAnalysis, error) {
 // MinTempo, MaxTempo, TargetTempo, MinTimeSignature, MaxTimeSignature,
 // TargetTimeSignature, MinValence, MaxValence, TargetValence.
 func (s *Spotify) GetRecommendations(params ...Param) (*Recommendation, error) {
+	return s.GetRecommendationsCtx(context.Background(), params...)
+}
+
+// GetRecommendationsCtx is the same as GetRecommendations, but it sends the request with the given context.
+func (s *Spotify) GetRecommendationsCtx(
+	ctx context.Context,
+	params ...Param,
+) (*Recommendation, error) {
 	recommendation := &Recommendation{}
-	err := s.Get(recommendation, "/recommendations", params...)
+	err := s.GetCtx(ctx, recommendation, "/recommendations", params...)
 	return recommendation, err
 }
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"strings"
 )
@@ -28,7 +29,7 @@ type User struct {
 	// This field is only available when the current user has granted access to the user-read-private scope.
 	ExplicitContent ExplicitContent `json:"explicit_content"`
 	// Known external URLs for this user.
-	ExternalURLs ExternalURL `json:"external_ur_ls"`
+	ExternalURLs ExternalURL `json:"external_urls"`
 	// Information about the followers of the user.
 	Followers Follower `json:"followers"`
 	// A link to the Web API endpoint for this user.
@@ -52,8 +53,13 @@ type User struct {
 //
 // Scopes: ScopeUserReadPrivate, UserReadEmail.
 func (s *Spotify) GetCurrentUserProfile() (*User, error) {
+	return s.GetCurrentUserProfileCtx(context.Background())
+}
+
+// GetCurrentUserProfileCtx is the same as GetCurrentUserProfile, but it sends the request with the given context.
+func (s *Spotify) GetCurrentUserProfileCtx(ctx context.Context) (*User, error) {
 	user := &User{}
-	err := s.Get(user, "/me")
+	err := s.GetCtx(ctx, user, "/me")
 	return user, err
 }
 
@@ -63,15 +69,29 @@ func (s *Spotify) GetCurrentUserProfile() (*User, error) {
 //
 // Scopes: ScopeUserTopRead.
 func (s *Spotify) GetUserTopItems(itemsType string, params ...Param) (*UserItemChunk, error) {
+	return s.GetUserTopItemsCtx(context.Background(), itemsType, params...)
+}
+
+// GetUserTopItemsCtx is the same as GetUserTopItems, but it sends the request with the given context.
+func (s *Spotify) GetUserTopItemsCtx(
+	ctx context.Context,
+	itemsType string,
+	params ...Param,
+) (*UserItemChunk, error) {
 	userItemChunk := &UserItemChunk{}
-	err := s.Get(userItemChunk, fmt.Sprintf("/me/top/%s", itemsType), params...)
+	err := s.GetCtx(ctx, userItemChunk, fmt.Sprintf("/me/top/%s", itemsType), params...)
 	return userItemChunk, err
 }
 
 // GetUserProfile obtains public profile information about a Spotify user.
 func (s *Spotify) GetUserProfile(id string) (*User, error) {
+	return s.GetUserProfileCtx(context.Background(), id)
+}
+
+// GetUserProfileCtx is the same as GetUserProfile, but it sends the request with the given context.
+func (s *Spotify) GetUserProfileCtx(ctx context.Context, id string) (*User, error) {
 	user := &User{}
-	err := s.Get(user, fmt.Sprintf("/me/%s", id))
+	err := s.GetCtx(ctx, user, fmt.Sprintf("/me/%s", id))
 	return user, err
 }
 
@@ -81,72 +101,142 @@ func (s *Spotify) GetUserProfile(id string) (*User, error) {
 //
 // Scopes: ScopePlaylistModifyPublic, ScopePlaylistModifyPrivate.
 func (s *Spotify) FollowPlaylist(playlistId string, properties []Property) error {
+	return s.FollowPlaylistCtx(context.Background(), playlistId, properties)
+}
+
+// FollowPlaylistCtx is the same as FollowPlaylist, but it sends the request with the given context.
+func (s *Spotify) FollowPlaylistCtx(
+	ctx context.Context,
+	playlistId string,
+	properties []Property,
+) error {
 	body, err := createBodyFromProperties(properties)
 	if err != nil {
 		return err
 	}
 
-	return s.Put(nil, fmt.Sprintf("/playlist/%s/followers", playlistId), body)
+	return s.PutCtx(ctx, nil, fmt.Sprintf("/playlist/%s/followers", playlistId), body)
 }
 
 // UnfollowPlaylist removes the current user as a follower of a playlist.
 //
 // Scopes: ScopePlaylistModifyPublic, ScopePlaylistModifyPrivate.
 func (s *Spotify) UnfollowPlaylist(playlistId string) error {
-	return s.Delete(nil, fmt.Sprintf("/playlist/%s/followers", playlistId), []byte{})
+	return s.UnfollowPlaylistCtx(context.Background(), playlistId)
+}
+
+// UnfollowPlaylistCtx is the same as UnfollowPlaylist, but it sends the request with the given context.
+func (s *Spotify) UnfollowPlaylistCtx(ctx context.Context, playlistId string) error {
+	return s.DeleteCtx(ctx, nil, fmt.Sprintf("/playlist/%s/followers", playlistId), []byte{})
 }
 
 // GetFollowedArtists obtains the current user's followed artists.
 //
 // Params: After, Limit.
+// The following pages can be fetched with the Paginator.
 //
 // Scopes: ScopeUserFollowRead.
-func (s *Spotify) GetFollowedArtists(idType string, params ...Param) (*FullArtistChunk, error) {
-	artist := &FullArtistChunk{}
-	err := s.Get(artist, fmt.Sprintf("/me/following?type=%s", idType), params...)
-	return artist, err
+func (s *Spotify) GetFollowedArtists(idType string, params ...Param) (*FullArtistCursorChunk, error) {
+	return s.GetFollowedArtistsCtx(context.Background(), idType, params...)
+}
+
+// GetFollowedArtistsCtx is the same as GetFollowedArtists, but it sends the request with the given context.
+func (s *Spotify) GetFollowedArtistsCtx(
+	ctx context.Context,
+	idType string,
+	params ...Param,
+) (*FullArtistCursorChunk, error) {
+	var w struct {
+		Artists FullArtistCursorChunk `json:"artists"`
+	}
+	err := s.GetCtx(ctx, &w, fmt.Sprintf("/me/following?type=%s", idType), params...)
+	return &w.Artists, err
 }
 
 // FollowArtistsOrUsers adds the current user as a follower of one or more artists or other Spotify users.
 //
 // Scopes: ScopeUserFollowModify.
 func (s *Spotify) FollowArtistsOrUsers(idType string, ids []string) error {
-	return s.Put(
-		nil,
-		fmt.Sprintf("/me/following?type=%s&ids=%s", idType, strings.Join(ids, ",")),
-		[]byte{},
-	)
+	return s.FollowArtistsOrUsersCtx(context.Background(), idType, ids)
+}
+
+// FollowArtistsOrUsersCtx is the same as FollowArtistsOrUsers, but it sends the request with the given context.
+func (s *Spotify) FollowArtistsOrUsersCtx(ctx context.Context, idType string, ids []string) error {
+	return batchEach(ctx, s, ids, maxFollowIds, func(ctx context.Context, ids []string) error {
+		return s.PutCtx(
+			ctx,
+			nil,
+			fmt.Sprintf("/me/following?type=%s&ids=%s", idType, strings.Join(ids, ",")),
+			[]byte{},
+		)
+	})
 }
 
 // UnfollowArtistsOrUsers removes the current user as a follower of one or more artists or other Spotify users.
 //
 // Scopes: ScopeUserFollowModify.
 func (s *Spotify) UnfollowArtistsOrUsers(idType string, ids []string) error {
-	return s.Delete(
-		nil,
-		fmt.Sprintf("/me/following?type=%s&ids=%s", idType, strings.Join(ids, ",")),
-		[]byte{},
-	)
+	return s.UnfollowArtistsOrUsersCtx(context.Background(), idType, ids)
+}
+
+// UnfollowArtistsOrUsersCtx is the same as UnfollowArtistsOrUsers, but it sends the request with the given context.
+func (s *Spotify) UnfollowArtistsOrUsersCtx(
+	ctx context.Context,
+	idType string,
+	ids []string,
+) error {
+	return batchEach(ctx, s, ids, maxFollowIds, func(ctx context.Context, ids []string) error {
+		return s.DeleteCtx(
+			ctx,
+			nil,
+			fmt.Sprintf("/me/following?type=%s&ids=%s", idType, strings.Join(ids, ",")),
+			[]byte{},
+		)
+	})
 }
 
 // CheckIfUserFollowsArtistsOrUsers checks to see if the current user is following one or more artists or other Spotify users.
 //
 // Scopes: ScopeUserFollowRead.
 func (s *Spotify) CheckIfUserFollowsArtistsOrUsers(idType string, ids []string) ([]bool, error) {
-	followInfo := []bool{}
-	err := s.Get(
-		&followInfo,
-		fmt.Sprintf("/me/following/contains?type=%s&ids=%s", idType, strings.Join(ids, ",")),
-	)
-	return followInfo, err
+	return s.CheckIfUserFollowsArtistsOrUsersCtx(context.Background(), idType, ids)
+}
+
+// CheckIfUserFollowsArtistsOrUsersCtx is the same as CheckIfUserFollowsArtistsOrUsers, but it sends the request with the given context.
+func (s *Spotify) CheckIfUserFollowsArtistsOrUsersCtx(
+	ctx context.Context,
+	idType string,
+	ids []string,
+) ([]bool, error) {
+	return batchIds(ctx, s, ids, maxFollowIds, func(ctx context.Context, ids []string) ([]bool, error) {
+		followInfo := []bool{}
+		err := s.GetCtx(
+			ctx,
+			&followInfo,
+			fmt.Sprintf("/me/following/contains?type=%s&ids=%s", idType, strings.Join(ids, ",")),
+		)
+		return followInfo, err
+	})
 }
 
 // CheckIfUsersFollowPlaylist checks to see if one or more Spotify users are following a specified playlist.
 func (s *Spotify) CheckIfUsersFollowPlaylist(playlistId string, ids []string) ([]bool, error) {
-	followInfo := []bool{}
-	err := s.Get(
-		&followInfo,
-		fmt.Sprintf("/playlists/%s/followers/contains?ids=%s", playlistId, strings.Join(ids, ",")),
-	)
-	return followInfo, err
+	return s.CheckIfUsersFollowPlaylistCtx(context.Background(), playlistId, ids)
+}
+
+// CheckIfUsersFollowPlaylistCtx is the same as CheckIfUsersFollowPlaylist, but it sends the request with the given context.
+func (s *Spotify) CheckIfUsersFollowPlaylistCtx(
+	ctx context.Context,
+	playlistId string,
+	ids []string,
+) ([]bool, error) {
+	return batchIds(ctx, s, ids, maxPlaylistFollowIds, func(ctx context.Context, ids []string) ([]bool, error) {
+		followInfo := []bool{}
+		err := s.GetCtx(
+			ctx,
+			&followInfo,
+			fmt.Sprintf("/playlists/%s/followers/contains?ids=%s", playlistId, strings.Join(ids, ",")),
+		)
+		return followInfo, err
+	})
 }
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"net/http"
 	"os"
 	"testing"
 )
@@ -93,8 +94,26 @@ func TestGetFollowedArtists(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	sourceArtist := &FullArtistChunk{}
-	testDiffs(t, body, sourceArtist, artist)
+	type followedArtists struct {
+		Artists *FullArtistCursorChunk `json:"artists"`
+	}
+	sourceArtist := &followedArtists{}
+	testDiffs(t, body, sourceArtist, &followedArtists{artist})
+}
+
+func TestGetFollowedArtistsEmpty(t *testing.T) {
+	server, spotify := testServer(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	defer server.Close()
+
+	artists, err := spotify.GetFollowedArtists("artist")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if artists == nil || len(artists.Items) != 0 {
+		t.Errorf("Expected the empty chunk, got %v", artists)
+	}
 }
 
 func TestFollowArtistsOrUsers(t *testing.T) {
@@ -1,8 +1,8 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 	"reflect"
 )
 
@@ -83,113 +83,350 @@ type AudioRecording struct {
 	Restrictions Restriction `json:"restrictions"`
 }
 
-// Item struct is used to correctly parse JSON "oneOf" type.
+// Item is used to parse the JSON "oneOf" type, such as the currently playing item or the items of the queue,
+// into the object of the type named by its "type" field.
+// The object can be checked with the type switch:
+//
+//	switch object := item.Object.(type) {
+//	case *FullTrack:
+//		...
+//	case *FullEpisode:
+//		...
+//	}
+//
+// The Item keeps the JSON it was decoded from, and encodes it back as it is, unless the object is changed,
+// so the fields the client does not know are not lost when the item is stored and read again.
+// Changed and newly created objects are encoded from their structs, with the "type" field set to their type.
 type Item struct {
-	Artist  FullArtist
-	Track   FullTrack
-	Episode FullEpisode
-	// Instead of checking every object for nil, this field can be used.
-	// Supported Types:
-	//
-	// - artist
-	//
-	// - track
-	//
-	// - episode
-	Type string
-}
-
-type ItemType int
-
-// Enum used to check on "oneOf" object type.
+	// The object of the item: *FullAlbum, *FullArtist, *FullAudiobook, *FullChapter, *FullEpisode,
+	// *FullPlaylist, *FullShow, *FullTrack, *User, or *UnknownItem for the types the client does not know, such as ads.
+	// It is nil if the item is null.
+	Object ItemObject
+	// The JSON the item was decoded from.
+	raw json.RawMessage
+}
+
+// ItemObject is implemented by every type the Item can hold. It is sealed, so it can not be implemented outside of the package.
+type ItemObject interface {
+	// ItemType returns the type of the object, as it is named by the Spotify API.
+	ItemType() ItemType
+	itemObject()
+}
+
+// ItemType is the type of the object held by the Item, as it is named by the Spotify API.
+type ItemType string
+
+// Types of the objects the Item can hold.
 const (
-	Artist ItemType = iota
-	Track
-	Episode
+	ItemAlbum     ItemType = "album"
+	ItemArtist    ItemType = "artist"
+	ItemAudiobook ItemType = "audiobook"
+	ItemChapter   ItemType = "chapter"
+	ItemEpisode   ItemType = "episode"
+	ItemPlaylist  ItemType = "playlist"
+	ItemShow      ItemType = "show"
+	ItemTrack     ItemType = "track"
+	ItemUser      ItemType = "user"
 )
 
-const itemTypeField = "Type"
+// UnknownItem holds the object of the type the client does not know, such as the ad, as the raw JSON.
+type UnknownItem struct {
+	// The type of the object. Empty if the object has no type.
+	Type ItemType
+	// The JSON of the object.
+	Raw json.RawMessage
+}
+
+func (*FullAlbum) ItemType() ItemType     { return ItemAlbum }
+func (*FullArtist) ItemType() ItemType    { return ItemArtist }
+func (*FullAudiobook) ItemType() ItemType { return ItemAudiobook }
+func (*FullChapter) ItemType() ItemType   { return ItemChapter }
+func (*FullEpisode) ItemType() ItemType   { return ItemEpisode }
+func (*FullPlaylist) ItemType() ItemType  { return ItemPlaylist }
+func (*FullShow) ItemType() ItemType      { return ItemShow }
+func (*FullTrack) ItemType() ItemType     { return ItemTrack }
+func (*User) ItemType() ItemType          { return ItemUser }
+func (u *UnknownItem) ItemType() ItemType { return u.Type }
+
+func (*FullAlbum) itemObject()     {}
+func (*FullArtist) itemObject()    {}
+func (*FullAudiobook) itemObject() {}
+func (*FullChapter) itemObject()   {}
+func (*FullEpisode) itemObject()   {}
+func (*FullPlaylist) itemObject()  {}
+func (*FullShow) itemObject()      {}
+func (*FullTrack) itemObject()     {}
+func (*User) itemObject()          {}
+func (*UnknownItem) itemObject()   {}
 
-func (it ItemType) String() string {
-	switch it {
-	case Artist:
-		return "artist"
-	case Track:
-		return "track"
-	case Episode:
-		return "episode"
+// newItemObject creates the empty object of the given type, or returns nil if the type is unknown.
+// The type is taken as bytes, so the known types are matched without allocating the string.
+func newItemObject(itemType []byte) ItemObject {
+	switch string(itemType) {
+	case string(ItemAlbum):
+		return &FullAlbum{}
+	case string(ItemArtist):
+		return &FullArtist{}
+	case string(ItemAudiobook):
+		return &FullAudiobook{}
+	case string(ItemChapter):
+		return &FullChapter{}
+	case string(ItemEpisode):
+		return &FullEpisode{}
+	case string(ItemPlaylist):
+		return &FullPlaylist{}
+	case string(ItemShow):
+		return &FullShow{}
+	case string(ItemTrack):
+		return &FullTrack{}
+	case string(ItemUser):
+		return &User{}
 	}
+	return nil
+}
 
-	return "missing type"
+// Type returns the type of the object held by the Item, or the empty string if the item is null.
+func (i Item) Type() ItemType {
+	if i.Object == nil {
+		return ""
+	}
+	return i.Object.ItemType()
 }
 
-// UnmarshalJson is a custom Unmarshaler implementation,
-// used to parse "oneOf" type into one of the supported by Item struct types.
+// UnmarshalJSON is a custom Unmarshaler implementation,
+// used to parse "oneOf" type into the object of the type named by its "type" field.
+// The type is found by scanning the object, which is then decoded once, straight into the struct of its type.
+// Objects of the unknown types are kept as the *UnknownItem.
 func (i *Item) UnmarshalJSON(data []byte) error {
+	i.Object, i.raw = nil, nil
 	if len(data) == 0 || string(data) == "null" {
 		return nil
 	}
 
-	pairs := make(map[string]interface{})
-	err := json.Unmarshal(data, &pairs)
+	itemType, err := sniffItemType(data)
 	if err != nil {
 		return err
 	}
+	raw := append(json.RawMessage(nil), data...)
+	object := newItemObject(itemType)
+	if object == nil {
+		i.Object = &UnknownItem{ItemType(itemType), raw}
+		return nil
+	}
+	if err := json.Unmarshal(data, object); err != nil {
+		return err
+	}
+	i.Object, i.raw = object, raw
+	return nil
+}
+
+// MarshalJSON is a custom Marshaler implementation, encoding the item into the JSON it was decoded from,
+// as long as its object is not changed. Otherwise, the object is encoded from its struct, with the "type" field set.
+func (i Item) MarshalJSON() ([]byte, error) {
+	switch object := i.Object.(type) {
+	case nil:
+		return []byte("null"), nil
+	case *UnknownItem:
+		if len(object.Raw) == 0 {
+			return []byte("null"), nil
+		}
+		return object.Raw, nil
+	}
+	if i.raw != nil && i.unchanged() {
+		return i.raw, nil
+	}
+
+	data, err := json.Marshal(i.Object)
+	if err != nil {
+		return nil, err
+	}
+	if itemType, _ := sniffItemType(data); string(itemType) == string(i.Object.ItemType()) {
+		return data, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	fields["type"], err = json.Marshal(i.Object.ItemType())
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
+
+// unchanged reports whether the object of the item is still the same as the one decoded from its JSON.
+func (i Item) unchanged() bool {
+	object := newItemObject([]byte(i.Object.ItemType()))
+	if object == nil || json.Unmarshal(i.raw, object) != nil {
+		return false
+	}
+	return reflect.DeepEqual(object, i.Object)
+}
+
+// Equal reports whether the items hold the equal objects, regardless of the JSON they were decoded from.
+func (i Item) Equal(other Item) bool {
+	return reflect.DeepEqual(i.Object, other.Object)
+}
+
+// sniffItemType returns the value of the "type" field of the JSON object, or nil if there is no such field.
+// Instead of decoding the object, it only scans its top-level fields, skipping the values of the other ones,
+// so the object is decoded once, straight into the struct of its type.
+// Objects, which can not be scanned, such as the ones with the escaped "type" field, are decoded the usual way.
+func sniffItemType(data []byte) ([]byte, error) {
+	if itemType, ok := scanItemType(data); ok {
+		return itemType, nil
+	}
 
-	itemType, ok := pairs["type"]
-	if !ok {
-		return fmt.Errorf("item type missing")
+	var header struct {
+		Type interface{} `json:"type"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, err
+	}
+	itemType, _ := header.Type.(string)
+	return []byte(itemType), nil
+}
+
+// scanItemType scans the top-level fields of the JSON object for the "type" one, returning its value.
+// It reports false if the data is not the object it can scan.
+func scanItemType(data []byte) ([]byte, bool) {
+	i := skipSpace(data, 0)
+	if i >= len(data) || data[i] != '{' {
+		return nil, false
+	}
+	i = skipSpace(data, i+1)
+	if i < len(data) && data[i] == '}' {
+		return nil, true
 	}
 
-	switch itemType {
-	case Artist.String():
-		a := FullArtist{}
-		if err := i.parse(data, a, Artist.String(), "Artist"); err == nil {
-			return nil
+	for i < len(data) {
+		key, next, ok := scanString(data, i)
+		if !ok {
+			return nil, false
+		}
+		i = skipSpace(data, next)
+		if i >= len(data) || data[i] != ':' {
+			return nil, false
 		}
-	case Track.String():
-		t := FullTrack{}
-		if err := i.parse(data, t, Track.String(), "Track"); err == nil {
-			return nil
+		i = skipSpace(data, i+1)
+
+		if string(key) == "type" {
+			if i < len(data) && data[i] == 'n' {
+				return nil, true
+			}
+			value, _, ok := scanString(data, i)
+			return value, ok
 		}
-	case Episode.String():
-		e := FullEpisode{}
-		if err := i.parse(data, e, Episode.String(), "Episode"); err == nil {
-			return nil
+		if i, ok = skipValue(data, i); !ok {
+			return nil, false
+		}
+
+		i = skipSpace(data, i)
+		if i >= len(data) {
+			return nil, false
+		}
+		switch data[i] {
+		case ',':
+			i = skipSpace(data, i+1)
+		case '}':
+			return nil, true
+		default:
+			return nil, false
 		}
 	}
+	return nil, false
+}
 
-	return fmt.Errorf("unsupported item type")
+// scanString returns the contents of the JSON string starting at i, and the index after it.
+// Strings with the escape sequences are not scanned, as their contents differ from the raw bytes.
+func scanString(data []byte, i int) ([]byte, int, bool) {
+	if i >= len(data) || data[i] != '"' {
+		return nil, 0, false
+	}
+	for j := i + 1; j < len(data); j++ {
+		switch data[j] {
+		case '"':
+			return data[i+1 : j], j + 1, true
+		case '\\':
+			return nil, 0, false
+		}
+	}
+	return nil, 0, false
 }
 
-// parse dynamically parses json into the appropriate Item field
-// and saves the type of the parsed object into "Type" field.
-func (i *Item) parse(data []byte, itemStruct interface{}, itemType, itemField string) error {
-	structValue := reflect.New(reflect.TypeOf(itemStruct)).Elem()
-	if err := json.Unmarshal(data, structValue.Addr().Interface()); err != nil {
-		return err
+// skipValue returns the index after the JSON value starting at i.
+// Nested objects and arrays are skipped by counting the brackets outside of the strings.
+// The data is expected to be the valid JSON, as the json package validates it before calling the UnmarshalJSON.
+func skipValue(data []byte, i int) (int, bool) {
+	depth := 0
+	for ; i < len(data); i++ {
+		switch data[i] {
+		case '"':
+			i++
+			for i < len(data) && data[i] != '"' {
+				if data[i] == '\\' {
+					i++
+				}
+				i++
+			}
+			if i >= len(data) {
+				return 0, false
+			}
+			if depth == 0 {
+				return i + 1, true
+			}
+		case '{', '[':
+			depth++
+		case '}', ']':
+			if depth == 0 {
+				return i, true
+			}
+			depth--
+			if depth == 0 {
+				return i + 1, true
+			}
+		case ',':
+			if depth == 0 {
+				return i, true
+			}
+		}
 	}
+	return 0, false
+}
 
-	itemValue := reflect.ValueOf(i).Elem()
-	itemValue.FieldByName(itemTypeField).SetString(itemType)
-	itemValue.FieldByName(itemField).Set(structValue)
-	return nil
+// skipSpace returns the index of the first non-whitespace byte starting from i.
+func skipSpace(data []byte, i int) int {
+	for i < len(data) && (data[i] == ' ' || data[i] == '\t' || data[i] == '\n' || data[i] == '\r') {
+		i++
+	}
+	return i
 }
 
 // GetAvailableGenreSeeds obtains a list of available genres seed parameter values for recommendations.
 func (s *Spotify) GetAvailableGenreSeeds() (*[]string, error) {
+	return s.GetAvailableGenreSeedsCtx(context.Background())
+}
+
+// GetAvailableGenreSeedsCtx is the same as GetAvailableGenreSeeds, but it sends the request with the given context.
+func (s *Spotify) GetAvailableGenreSeedsCtx(ctx context.Context) (*[]string, error) {
 	var w struct {
 		Genres *[]string `json:"genres"`
 	}
-	err := s.Get(&w, "/recommendations/available-genre-seeds")
+	err := s.GetCtx(ctx, &w, "/recommendations/available-genre-seeds")
 	return w.Genres, err
 }
 
 // GetAvailableMarkets obtains the list of markets where Spotify is available.
 func (s *Spotify) GetAvailableMarkets() (*[]string, error) {
+	return s.GetAvailableMarketsCtx(context.Background())
+}
+
+// GetAvailableMarketsCtx is the same as GetAvailableMarkets, but it sends the request with the given context.
+func (s *Spotify) GetAvailableMarketsCtx(ctx context.Context) (*[]string, error) {
 	var w struct {
 		Markets *[]string `json:"markets"`
 	}
-	err := s.Get(&w, "/markets")
+	err := s.GetCtx(ctx, &w, "/markets")
 	return w.Markets, err
 }
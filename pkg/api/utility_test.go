@@ -1,8 +1,13 @@
 package api
 
 import (
+	"bytes"
+	"encoding/json"
 	"os"
+	"reflect"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestGetAvailableGenreSeeds(t *testing.T) {
@@ -52,3 +57,221 @@ func TestGetAvailableMarkets(t *testing.T) {
 
 	testDiffs(t, body, sourceMarkets, targetMarkets)
 }
+
+const testItems = `[
+	{"type":"album","id":"album-id","name":"album","album_type":"single","total_tracks":1},
+	{"type":"artist","id":"artist-id","name":"artist","popularity":50},
+	{"type":"audiobook","id":"audiobook-id","name":"audiobook","total_chapters":3},
+	{"type":"chapter","id":"chapter-id","name":"chapter","chapter_number":2},
+	{"type":"episode","id":"episode-id","name":"episode","duration_ms":1000},
+	{"type":"playlist","id":"playlist-id","name":"playlist","public":true},
+	{"type":"show","id":"show-id","name":"show","total_episodes":4},
+	{"type":"track","id":"track-id","name":"track","popularity":70,"extra_field":{"a":[1,2]}},
+	{"type":"user","id":"user-id","display_name":"user"},
+	{"type":"ad","id":"ad-id","duration_ms":30000},
+	{"id":"no-type"},
+	null
+]`
+
+func TestItemUnmarshal(t *testing.T) {
+	items := []Item{}
+	if err := json.Unmarshal([]byte(testItems), &items); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []ItemType{
+		ItemAlbum, ItemArtist, ItemAudiobook, ItemChapter, ItemEpisode,
+		ItemPlaylist, ItemShow, ItemTrack, ItemUser, "ad", "", "",
+	}
+	if len(items) != len(expected) {
+		t.Fatalf("Expected %d items, got %d", len(expected), len(items))
+	}
+	for i, item := range items {
+		if item.Type() != expected[i] {
+			t.Errorf("Expected %q item at %d, got %q", expected[i], i, item.Type())
+		}
+	}
+
+	if track, ok := items[7].Object.(*FullTrack); !ok || track.Id != "track-id" || track.Popularity != 70 {
+		t.Errorf("Unexpected track: %+v", items[7].Object)
+	}
+	if audiobook, ok := items[2].Object.(*FullAudiobook); !ok || audiobook.TotalChapters != 3 {
+		t.Errorf("Unexpected audiobook: %+v", items[2].Object)
+	}
+	if ad, ok := items[9].Object.(*UnknownItem); !ok || string(ad.Raw) != `{"type":"ad","id":"ad-id","duration_ms":30000}` {
+		t.Errorf("Unexpected ad: %+v", items[9].Object)
+	}
+	if items[11].Object != nil {
+		t.Errorf("Expected null item, got %+v", items[11].Object)
+	}
+}
+
+func TestItemRoundTrip(t *testing.T) {
+	items := []Item{}
+	if err := json.Unmarshal([]byte(testItems), &items); err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(items)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &bytes.Buffer{}
+	if err := json.Compact(expected, []byte(testItems)); err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != expected.String() {
+		t.Errorf("Expected %s, got %s", expected, data)
+	}
+
+	decoded := []Item{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(items, decoded); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestItemMarshalChanged(t *testing.T) {
+	items := []Item{}
+	if err := json.Unmarshal([]byte(testItems), &items); err != nil {
+		t.Fatal(err)
+	}
+	items[7].Object.(*FullTrack).Name = "changed"
+
+	for _, item := range []Item{items[7], {Object: &FullTrack{}}, {Object: &User{}}} {
+		data, err := json.Marshal(item)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded := Item{}
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatal(err)
+		}
+		if decoded.Type() != item.Type() {
+			t.Errorf("Expected %q item, got %q from %s", item.Type(), decoded.Type(), data)
+		}
+	}
+
+	data, err := json.Marshal(items[7])
+	if err != nil {
+		t.Fatal(err)
+	}
+	track := &FullTrack{}
+	if err := json.Unmarshal(data, track); err != nil {
+		t.Fatal(err)
+	}
+	if track.Name != "changed" || track.Id != "track-id" || track.Type != "track" {
+		t.Errorf("Expected changed track, got %s", data)
+	}
+}
+
+func TestSniffItemType(t *testing.T) {
+	tests := []struct {
+		data     string
+		expected string
+	}{
+		{`{"type":"track"}`, "track"},
+		{` { "id" : "x" , "type" : "episode" } `, "episode"},
+		{`{"album":{"type":"album","artists":[{"type":"artist"}]},"type":"track"}`, "track"},
+		{`{"name":"quote \" and { brace","tags":["]",{"}":1}],"n":-1.5e3,"ok":true,"type":"show"}`, "show"},
+		{`{"\u0074ype":"chapter"}`, "chapter"},
+		{`{"type":"\u0061d"}`, "ad"},
+		{`{"type":5}`, ""},
+		{`{"type":null}`, ""},
+		{`{"id":"x"}`, ""},
+		{`{}`, ""},
+	}
+	for _, test := range tests {
+		itemType, err := sniffItemType([]byte(test.data))
+		if err != nil {
+			t.Errorf("Unexpected error for %s: %v", test.data, err)
+			continue
+		}
+		if string(itemType) != test.expected {
+			t.Errorf("Expected %q for %s, got %q", test.expected, test.data, itemType)
+		}
+	}
+
+	if _, err := sniffItemType([]byte(`[1,2]`)); err == nil {
+		t.Errorf("Expected error for the array")
+	}
+}
+
+// testTwoPassItem decodes the item the way it was decoded before the type was scanned:
+// into the map first, to find the type, and then into the struct of the type.
+func testTwoPassItem(data []byte) (ItemObject, error) {
+	pairs := make(map[string]interface{})
+	if err := json.Unmarshal(data, &pairs); err != nil {
+		return nil, err
+	}
+	itemType, _ := pairs["type"].(string)
+	object := newItemObject([]byte(itemType))
+	if object == nil {
+		return &UnknownItem{ItemType(itemType), append(json.RawMessage(nil), data...)}, nil
+	}
+	structValue := reflect.New(reflect.TypeOf(object).Elem())
+	if err := json.Unmarshal(data, structValue.Interface()); err != nil {
+		return nil, err
+	}
+	return structValue.Interface().(ItemObject), nil
+}
+
+func BenchmarkItemUnmarshal(b *testing.B) {
+	body, err := os.ReadFile("testdata/userQueue.json")
+	if err != nil {
+		b.Fatal(err)
+	}
+	var queue struct {
+		CurrentlyPlaying json.RawMessage   `json:"currently_playing"`
+		Queue            []json.RawMessage `json:"queue"`
+	}
+	if err := json.Unmarshal(body, &queue); err != nil {
+		b.Fatal(err)
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal([]byte(testItems), &items); err != nil {
+		b.Fatal(err)
+	}
+
+	benchmarks := []struct {
+		name  string
+		items []json.RawMessage
+	}{
+		{"queue", append([]json.RawMessage{queue.CurrentlyPlaying}, queue.Queue...)},
+		{"types", items[:len(items)-1]},
+	}
+	for _, bench := range benchmarks {
+		b.Run(bench.name+"/singlePass", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				for _, data := range bench.items {
+					item := Item{}
+					if err := item.UnmarshalJSON(data); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+		b.Run(bench.name+"/twoPass", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				for _, data := range bench.items {
+					if _, err := testTwoPassItem(data); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+
+	b.Run("sniff", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := sniffItemType(queue.CurrentlyPlaying); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
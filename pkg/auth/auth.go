@@ -87,6 +87,11 @@ func (s *Service) Exchange(
 	return s.conf.Exchange(ctx, code, opts...)
 }
 
+// TokenSource returns the token source, which refreshes the given token when it expires.
+func (s *Service) TokenSource(ctx context.Context, token *oauth2.Token) oauth2.TokenSource {
+	return s.conf.TokenSource(ctx, token)
+}
+
 // NewService creates a service, with default settings.
 // It loads Client Id and Secret from the Environment.
 // ClientId = SPOTIFY_CLIENT_ID
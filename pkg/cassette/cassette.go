@@ -0,0 +1,266 @@
+// Package cassette provides the HTTP transport, which records the requests made by the Spotify client
+// to the cassette file, and replays them later, so the tests can run offline.
+//
+// The Recorder is plugged into the client with api.WithTransport:
+//
+//	recorder, err := cassette.New("testdata/playlist.json", cassette.ModeReplay)
+//	spotify := api.NewSpotifyClient(ctx, api.WithTransport(recorder))
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/Alieksieiev0/sgotify/internal/redact"
+)
+
+// ErrNoInteraction is returned in the replay mode, when the cassette has no interaction matching the request.
+var ErrNoInteraction = errors.New("cassette: no matching interaction")
+
+// Mode decides whether the Recorder sends the requests and records them, or replays the recorded ones.
+type Mode int
+
+const (
+	// ModeReplay serves the responses from the cassette, without sending the requests.
+	ModeReplay Mode = iota
+	// ModeRecord sends the requests and records them to the cassette, once the Recorder is stopped.
+	ModeRecord
+)
+
+// Request is the recorded request.
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Response is the recorded response.
+type Response struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Interaction is the request and the response it received.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Cassette is the content of the cassette file.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Matcher checks if the request matches the recorded one.
+type Matcher func(req *http.Request, recorded Request) bool
+
+// MatchMethod matches the requests with the same method.
+func MatchMethod(req *http.Request, recorded Request) bool {
+	return req.Method == recorded.Method
+}
+
+// MatchPath matches the requests with the same path.
+func MatchPath(req *http.Request, recorded Request) bool {
+	u, err := url.Parse(recorded.URL)
+	return err == nil && req.URL.Path == u.Path
+}
+
+// MatchQuery matches the requests with the same query params, regardless of their order.
+// The credentials in the params are scrubbed before comparing, as they are in the cassette.
+func MatchQuery(req *http.Request, recorded Request) bool {
+	u, err := url.Parse(recorded.URL)
+	return err == nil && redact.Query(req.URL.Query()).Encode() == u.Query().Encode()
+}
+
+// DefaultMatchers are used by the Recorder, unless WithMatchers is supplied.
+var DefaultMatchers = []Matcher{MatchMethod, MatchPath, MatchQuery}
+
+// Option is used to conveniently add additional settings to the Recorder.
+type Option func(r *Recorder)
+
+// WithMatchers sets the matchers, every one of which must match the request to replay the interaction.
+func WithMatchers(matchers ...Matcher) Option {
+	return func(r *Recorder) {
+		r.matchers = matchers
+	}
+}
+
+// WithTransport sets the transport used to send the requests in the record mode,
+// in place of the http.DefaultTransport.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(r *Recorder) {
+		r.transport = transport
+	}
+}
+
+// Recorder is the http.RoundTripper, which records or replays the interactions of the cassette file.
+// It is safe for concurrent use by multiple goroutines.
+// It is recommended to create Recorder through the New function.
+type Recorder struct {
+	mu        sync.Mutex
+	path      string
+	mode      Mode
+	cassette  *Cassette
+	matchers  []Matcher
+	transport http.RoundTripper
+	// Whether the interaction with the same index was already replayed.
+	replayed []bool
+}
+
+// New creates the Recorder of the cassette file at the given path.
+// In the replay mode, the file must exist. In the record mode, it is created or replaced by Stop.
+func New(path string, mode Mode, opts ...Option) (*Recorder, error) {
+	r := &Recorder{
+		path:      path,
+		mode:      mode,
+		cassette:  &Cassette{},
+		matchers:  DefaultMatchers,
+		transport: http.DefaultTransport,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if mode == ModeReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, r.cassette); err != nil {
+			return nil, fmt.Errorf("cassette %s: %w", path, err)
+		}
+		r.replayed = make([]bool, len(r.cassette.Interactions))
+	}
+	return r, nil
+}
+
+// RoundTrip records or replays the request, depending on the mode of the Recorder.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.mode == ModeRecord {
+		return r.record(req)
+	}
+	return r.replay(req)
+}
+
+// Stop writes the recorded interactions to the cassette file. It has no effect in the replay mode.
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, data, 0o644)
+}
+
+// record sends the request and keeps the interaction, with the credentials scrubbed.
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	res, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	resBody, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(resBody))
+
+	recordedURL := *req.URL
+	recordedURL.RawQuery = redact.Query(req.URL.Query()).Encode()
+	interaction := &Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    recordedURL.String(),
+			Header: redact.Header(req.Header),
+			Body:   redact.Body(reqBody),
+		},
+		Response: Response{
+			Status: res.StatusCode,
+			Header: redact.Header(res.Header),
+			Body:   redact.Body(resBody),
+		},
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	return res, nil
+}
+
+// replay serves the response of the first interaction matching the request, which was not replayed yet.
+// Once all the matching interactions are replayed, the last one is served again.
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	match := -1
+	for i, interaction := range r.cassette.Interactions {
+		if !r.matches(req, interaction.Request) {
+			continue
+		}
+		match = i
+		if !r.replayed[i] {
+			break
+		}
+	}
+	if match < 0 {
+		return nil, fmt.Errorf("%w: %s %s in %s", ErrNoInteraction, req.Method, req.URL, r.path)
+	}
+	r.replayed[match] = true
+
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	recorded := r.cassette.Interactions[match].Response
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.Status, http.StatusText(recorded.Status)),
+		StatusCode:    recorded.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        recorded.Header.Clone(),
+		Body:          io.NopCloser(strings.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}, nil
+}
+
+// matches checks if every matcher of the Recorder matches the request.
+func (r *Recorder) matches(req *http.Request, recorded Request) bool {
+	for _, matcher := range r.matchers {
+		if !matcher(req, recorded) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,94 @@
+package cassette
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Alieksieiev0/sgotify/pkg/api"
+	"golang.org/x/oauth2"
+)
+
+const testId = "4aawyAB9vmqN3uQ7FjRGTy"
+
+func testClient(recorder *Recorder, url string) *api.Spotify {
+	return api.NewSpotifyClient(
+		context.Background(),
+		api.WithBaseURL(url),
+		api.WithTransport(recorder),
+		api.WithToken(&oauth2.Token{AccessToken: "secret"}),
+		api.WithRetryPolicy(api.RetryPolicy{}),
+	)
+}
+
+func TestRecordReplay(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		_, err := w.Write([]byte(`{"id":"` + testId + `","name":"` + r.URL.Query().Get("market") + `"}`))
+		if err != nil {
+			panic(err)
+		}
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "track.json")
+	recorder, err := New(path, ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spotify := testClient(recorder, server.URL)
+	for _, market := range []string{"ES", "US"} {
+		if _, err := spotify.GetTrack(testId, api.Market(market)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := recorder.Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "secret") {
+		t.Errorf("Expected token to be scrubbed, got %s", data)
+	}
+
+	recorder, err = New(path, ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spotify = testClient(recorder, server.URL)
+	for _, market := range []string{"US", "ES"} {
+		track, err := spotify.GetTrack(testId, api.Market(market))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if track.Id != testId || track.Name != market {
+			t.Errorf("Expected the response recorded for %s, got %+v", market, track)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("Expected replayed requests not to be sent, got %d calls", calls)
+	}
+
+	_, err = spotify.GetTrack(testId, api.Market("GB"))
+	if !errors.Is(err, ErrNoInteraction) {
+		t.Errorf("Expected %v, got %v", ErrNoInteraction, err)
+	}
+
+	recorder, err = New(path, ModeReplay, WithMatchers(MatchMethod, MatchPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := testClient(recorder, server.URL).GetTrack(testId, api.Market("GB")); err != nil {
+		t.Errorf("Expected query to be ignored, got %v", err)
+	}
+}
@@ -51,6 +51,12 @@ func (t Terminal) Authorize(ctx context.Context) (*oauth2.Token, error) {
 	return token, nil
 }
 
+// TokenSource returns the token source, which refreshes the given token when it expires.
+// It can be passed to the Spotify client with api.WithTokenSource.
+func (t Terminal) TokenSource(ctx context.Context, token *oauth2.Token) oauth2.TokenSource {
+	return t.service.TokenSource(ctx, token)
+}
+
 // runCallbackServer opens the HTTP server and runs it until the interrupt signal is received.
 func runCallbackServer(code *string) {
 	http.HandleFunc("/callback", handleCallback(code))
@@ -0,0 +1,144 @@
+package spotifyid
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Alieksieiev0/sgotify/pkg/api"
+)
+
+// The maximum number of the redirects followed while resolving the short link.
+const maxRedirects = 10
+
+// DefaultShortLinkHosts are the hosts of the short links Spotify shares, which redirect to the open.spotify.com.
+var DefaultShortLinkHosts = []string{"spotify.link", "spotify.app.link", "spoti.fi"}
+
+// Resolver turns the links users share, including the short ones, into the URIs of the Spotify objects,
+// and fetches the objects they refer to.
+// It can be used from many goroutines at once.
+type Resolver struct {
+	client *http.Client
+	hosts  map[string]bool
+}
+
+// NewResolver creates the Resolver, which follows the redirects of the short links with the given client.
+// If the client is nil, the http.DefaultClient is used.
+// The hosts of the short links can be supplied in place of the DefaultShortLinkHosts, for example to point the Resolver to a test server.
+func NewResolver(client *http.Client, shortLinkHosts ...string) *Resolver {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if len(shortLinkHosts) == 0 {
+		shortLinkHosts = DefaultShortLinkHosts
+	}
+
+	noRedirects := *client
+	noRedirects.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	r := &Resolver{client: &noRedirects, hosts: map[string]bool{}}
+	for _, host := range shortLinkHosts {
+		r.hosts[host] = true
+	}
+	return r
+}
+
+// Resolve returns the URI of the object the link refers to.
+// Every form accepted by Parse is resolved without any request,
+// while the short links are resolved by following their redirects until they lead to the open.spotify.com.
+// Links pasted without the scheme, such as "spotify.link/abc123", are taken as https ones.
+func (r *Resolver) Resolve(ctx context.Context, link string) (URI, error) {
+	link = r.withScheme(strings.TrimSpace(link))
+	if uri, err := Parse(link); err == nil {
+		return uri, nil
+	}
+
+	for i := 0; i < maxRedirects; i++ {
+		parsedUrl, err := url.Parse(link)
+		if err != nil || !r.hosts[parsedUrl.Host] {
+			return URI{}, fmt.Errorf("%w uri or link %q", ErrInvalid, link)
+		}
+
+		location, err := r.redirect(ctx, parsedUrl)
+		if err != nil {
+			return URI{}, err
+		}
+		if uri, err := Parse(location); err == nil {
+			return uri, nil
+		}
+		link = location
+	}
+	return URI{}, fmt.Errorf("spotifyid: too many redirects resolving %q", link)
+}
+
+// withScheme adds the https scheme to the link without one, if it starts with the host of the short or the regular links.
+func (r *Resolver) withScheme(link string) string {
+	if strings.Contains(link, "://") {
+		return link
+	}
+	host, _, _ := strings.Cut(link, "/")
+	if r.hosts[host] || linkHosts[host] {
+		return "https://" + link
+	}
+	return link
+}
+
+// redirect returns the location the short link redirects to.
+func (r *Resolver) redirect(ctx context.Context, link *url.URL) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	res, err := r.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	res.Body.Close()
+
+	location, err := res.Location()
+	if err != nil {
+		return "", fmt.Errorf("%w short link %q: %d without redirect", ErrInvalid, link, res.StatusCode)
+	}
+	return location.String(), nil
+}
+
+// Fetch resolves the link and fetches the object it refers to with the client,
+// returning it as the pointer to the api type of its kind, such as *api.FullTrack or *api.FullPlaylist.
+func (r *Resolver) Fetch(ctx context.Context, s *api.Spotify, link string) (URI, interface{}, error) {
+	uri, err := r.Resolve(ctx, link)
+	if err != nil {
+		return URI{}, nil, err
+	}
+	object, err := Fetch(ctx, s, uri)
+	return uri, object, err
+}
+
+// Fetch fetches the object the URI refers to with the client,
+// returning it as the pointer to the api type of its kind, such as *api.FullTrack or *api.FullPlaylist.
+func Fetch(ctx context.Context, s *api.Spotify, uri URI) (interface{}, error) {
+	id := uri.ID.String()
+	switch uri.Kind {
+	case KindAlbum:
+		return s.GetAlbumCtx(ctx, id)
+	case KindArtist:
+		return s.GetArtistCtx(ctx, id)
+	case KindAudiobook:
+		return s.GetAudiobookCtx(ctx, id)
+	case KindChapter:
+		return s.GetChapterCtx(ctx, id)
+	case KindEpisode:
+		return s.GetEpisodeCtx(ctx, id)
+	case KindPlaylist:
+		return s.GetPlaylistCtx(ctx, id)
+	case KindShow:
+		return s.GetShowCtx(ctx, id)
+	case KindTrack:
+		return s.GetTrackCtx(ctx, id)
+	case KindUser:
+		return api.GetAs[api.User](ctx, s, "/users/"+url.PathEscape(id))
+	}
+	return nil, fmt.Errorf("%w kind %q", ErrInvalid, uri.Kind)
+}
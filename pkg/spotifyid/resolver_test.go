@@ -0,0 +1,126 @@
+package spotifyid
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/Alieksieiev0/sgotify/pkg/api"
+)
+
+func testShortLinkServer() *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/track":
+			http.Redirect(w, r, "https://open.spotify.com/intl-de/track/"+testId+"?si=abc", http.StatusFound)
+		case "/hop":
+			http.Redirect(w, r, "/track", http.StatusMovedPermanently)
+		case "/loop":
+			http.Redirect(w, r, "/loop", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+}
+
+func testResolver(server *httptest.Server) *Resolver {
+	serverUrl, err := url.Parse(server.URL)
+	if err != nil {
+		panic(err)
+	}
+	return NewResolver(server.Client(), serverUrl.Host)
+}
+
+func TestResolve(t *testing.T) {
+	server := testShortLinkServer()
+	defer server.Close()
+	resolver := testResolver(server)
+
+	for _, link := range []string{
+		server.URL + "/track",
+		server.URL + "/hop",
+		strings.TrimPrefix(server.URL, "https://") + "/track",
+		"open.spotify.com/track/" + testId,
+		"https://open.spotify.com/embed/track/" + testId + "?utm_source=generator",
+		"spotify:track:" + testId,
+	} {
+		uri, err := resolver.Resolve(context.Background(), link)
+		if err != nil {
+			t.Errorf("Unexpected error for %q: %v", link, err)
+			continue
+		}
+		if uri != (URI{KindTrack, testId}) {
+			t.Errorf("Unexpected URI for %q: %v", link, uri)
+		}
+	}
+
+	uri, err := resolver.Resolve(context.Background(), "https://open.spotify.com/embed-podcast/episode/"+testId)
+	if err != nil || uri != (URI{KindEpisode, testId}) {
+		t.Errorf("Unexpected URI of the embed episode: %v %v", uri, err)
+	}
+}
+
+func TestResolveInvalid(t *testing.T) {
+	server := testShortLinkServer()
+	defer server.Close()
+	resolver := testResolver(server)
+
+	for _, link := range []string{
+		server.URL + "/page",
+		"https://example.com/track/" + testId,
+	} {
+		_, err := resolver.Resolve(context.Background(), link)
+		if !errors.Is(err, ErrInvalid) {
+			t.Errorf("Expected %v for %q, got %v", ErrInvalid, link, err)
+		}
+	}
+
+	_, err := resolver.Resolve(context.Background(), server.URL+"/loop")
+	if err == nil {
+		t.Errorf("Expected redirect loop to fail")
+	}
+}
+
+func TestResolverFetch(t *testing.T) {
+	short := testShortLinkServer()
+	defer short.Close()
+
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := w.Write([]byte(`{"id":"` + testId + `","name":"test"}`)); err != nil {
+			panic(err)
+		}
+	}))
+	defer server.Close()
+	spotify := api.NewSpotifyClient(
+		context.Background(),
+		api.WithBaseURL(server.URL),
+		api.WithRetryPolicy(api.RetryPolicy{}),
+	)
+
+	uri, object, err := testResolver(short).Fetch(context.Background(), spotify, short.URL+"/track")
+	if err != nil {
+		t.Fatal(err)
+	}
+	track, ok := object.(*api.FullTrack)
+	if !ok {
+		t.Fatalf("Expected *api.FullTrack, got %T", object)
+	}
+	if uri.Kind != KindTrack || track.Id != testId || path != "/tracks/"+testId {
+		t.Errorf("Unexpected track: %v %s %s", uri, track.Id, path)
+	}
+
+	object, err = Fetch(context.Background(), spotify, MustParse("https://open.spotify.com/user/someone"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := object.(*api.User); !ok || path != "/users/someone" {
+		t.Errorf("Unexpected user: %T %s", object, path)
+	}
+}
@@ -0,0 +1,214 @@
+// Package spotifyid provides the types of the Spotify IDs and URIs, which validate them
+// before they are sent to the Spotify API, and convert them into the forms its methods need.
+//
+// The URI is parsed from any form Spotify uses to refer to the object:
+//
+//	uri, err := spotifyid.Parse("https://open.spotify.com/intl-de/track/6rqhFgbbKwnb9MLmUQDhG6?si=1f2e")
+//	...
+//	track, err := spotify.GetTrack(uri.ID.String())
+//	err = spotify.AddItemToPlaybackQueue(uri.String())
+package spotifyid
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ErrInvalid is matched by the errors returned when the ID, the URI or the link cannot be parsed.
+var ErrInvalid = errors.New("spotifyid: invalid")
+
+// The length of the base62 IDs of every object, except for the users.
+const idLength = 22
+
+// The hosts of the links to the Spotify objects.
+var linkHosts = map[string]bool{
+	"open.spotify.com": true,
+	"play.spotify.com": true,
+}
+
+// The first segments of the paths of the embed links, like "/embed/track/6rqhFgbbKwnb9MLmUQDhG6".
+var embedSegments = map[string]bool{
+	"embed":         true,
+	"embed-podcast": true,
+}
+
+// Kind is the type of the Spotify object the ID refers to.
+type Kind string
+
+// Kinds of the Spotify objects.
+const (
+	KindAlbum     Kind = "album"
+	KindArtist    Kind = "artist"
+	KindAudiobook Kind = "audiobook"
+	KindChapter   Kind = "chapter"
+	KindEpisode   Kind = "episode"
+	KindPlaylist  Kind = "playlist"
+	KindShow      Kind = "show"
+	KindTrack     Kind = "track"
+	KindUser      Kind = "user"
+)
+
+// Valid reports whether the kind is one of the known kinds of the Spotify objects.
+func (k Kind) Valid() bool {
+	switch k {
+	case KindAlbum, KindArtist, KindAudiobook, KindChapter, KindEpisode,
+		KindPlaylist, KindShow, KindTrack, KindUser:
+		return true
+	}
+	return false
+}
+
+// ID is the base62 identifier of the Spotify object, for example "6rqhFgbbKwnb9MLmUQDhG6".
+type ID string
+
+// ParseID checks that the string is the valid base62 ID, and returns it as the ID.
+func ParseID(s string) (ID, error) {
+	id := ID(s)
+	if !id.Valid() {
+		return "", fmt.Errorf("%w id %q", ErrInvalid, s)
+	}
+	return id, nil
+}
+
+// Valid reports whether the ID consists of 22 base62 characters.
+func (id ID) Valid() bool {
+	if len(id) != idLength {
+		return false
+	}
+	for _, c := range []byte(id) {
+		if !isBase62(c) {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the ID as the string, the form the methods taking the id of the object need.
+func (id ID) String() string {
+	return string(id)
+}
+
+// URI refers to the Spotify object of the specific kind, for example "spotify:track:6rqhFgbbKwnb9MLmUQDhG6".
+// IDs of the users are their usernames, so they are not limited to base62 IDs.
+type URI struct {
+	Kind Kind
+	ID   ID
+}
+
+// New creates the URI of the object of the given kind, validating both the kind and the id.
+func New(kind Kind, id string) (URI, error) {
+	uri := URI{kind, ID(id)}
+	if !uri.Valid() {
+		return URI{}, fmt.Errorf("%w %s id %q", ErrInvalid, kind, id)
+	}
+	return uri, nil
+}
+
+// Parse parses the Spotify URI, such as "spotify:track:6rqhFgbbKwnb9MLmUQDhG6",
+// or the link, such as "https://open.spotify.com/track/6rqhFgbbKwnb9MLmUQDhG6".
+// Links with the locale prefix, like "/intl-de/", and with the query, like "?si=...", are accepted,
+// as well as the embed links and the legacy URIs and links to the playlists, which include the owner of the playlist.
+// The short links, such as "https://spotify.link/...", are resolved by the Resolver.
+func Parse(s string) (URI, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "spotify:") {
+		return parseSegments(s, strings.Split(strings.TrimPrefix(s, "spotify:"), ":"))
+	}
+
+	link, err := url.Parse(s)
+	if err != nil || (link.Scheme != "https" && link.Scheme != "http") || !linkHosts[link.Host] {
+		return URI{}, fmt.Errorf("%w uri or link %q", ErrInvalid, s)
+	}
+	segments := strings.Split(strings.Trim(link.Path, "/"), "/")
+	if strings.HasPrefix(segments[0], "intl-") {
+		segments = segments[1:]
+	}
+	if len(segments) > 0 && embedSegments[segments[0]] {
+		segments = segments[1:]
+	}
+	return parseSegments(s, segments)
+}
+
+// parseSegments parses the kind and the id from the segments of the URI or the path of the link,
+// like ["track", "6rqhFgbbKwnb9MLmUQDhG6"] or the legacy ["user", "name", "playlist", "37i9dQZF1DXcBWIGoYBM5M"].
+func parseSegments(s string, segments []string) (URI, error) {
+	if len(segments) == 4 && segments[0] == string(KindUser) && segments[2] == string(KindPlaylist) {
+		segments = segments[2:]
+	}
+	if len(segments) != 2 {
+		return URI{}, fmt.Errorf("%w uri or link %q", ErrInvalid, s)
+	}
+
+	uri := URI{Kind(segments[0]), ID(segments[1])}
+	if !uri.Valid() {
+		return URI{}, fmt.Errorf("%w uri or link %q", ErrInvalid, s)
+	}
+	return uri, nil
+}
+
+// MustParse is the same as Parse, but it panics if the URI cannot be parsed.
+// It is intended for the URIs known in advance, such as the ones in the tests.
+func MustParse(s string) URI {
+	uri, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return uri
+}
+
+// Valid reports whether the kind of the URI is known, and the ID is valid for it.
+func (u URI) Valid() bool {
+	if u.Kind == KindUser {
+		return u.ID != "" && !strings.ContainsAny(string(u.ID), ":/?# ")
+	}
+	return u.Kind.Valid() && u.ID.Valid()
+}
+
+// String returns the Spotify URI, the form the methods taking the URIs, such as AddItemToPlaybackQueue, need.
+func (u URI) String() string {
+	return fmt.Sprintf("spotify:%s:%s", u.Kind, u.ID)
+}
+
+// URL returns the link to the object on the open.spotify.com.
+func (u URI) URL() string {
+	return fmt.Sprintf("https://open.spotify.com/%s/%s", u.Kind, url.PathEscape(string(u.ID)))
+}
+
+// MarshalText encodes the URI as the Spotify URI, so it is stored as the string in JSON.
+func (u URI) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText parses the URI from any form accepted by Parse.
+func (u *URI) UnmarshalText(text []byte) error {
+	uri, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*u = uri
+	return nil
+}
+
+// IDs returns the IDs of the URIs as strings, the form the methods taking several ids, such as GetTracks, need.
+func IDs(uris ...URI) []string {
+	ids := make([]string, len(uris))
+	for i, uri := range uris {
+		ids[i] = uri.ID.String()
+	}
+	return ids
+}
+
+// Strings returns the URIs as strings, the form the properties taking several URIs, such as PropertyURIs, need.
+func Strings(uris ...URI) []string {
+	strs := make([]string, len(uris))
+	for i, uri := range uris {
+		strs[i] = uri.String()
+	}
+	return strs
+}
+
+func isBase62(c byte) bool {
+	return ('0' <= c && c <= '9') || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
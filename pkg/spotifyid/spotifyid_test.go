@@ -0,0 +1,104 @@
+package spotifyid
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+const testId = "6rqhFgbbKwnb9MLmUQDhG6"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected URI
+	}{
+		{"spotify:track:" + testId, URI{KindTrack, testId}},
+		{"  spotify:album:" + testId + "\n", URI{KindAlbum, testId}},
+		{"https://open.spotify.com/track/" + testId, URI{KindTrack, testId}},
+		{"https://open.spotify.com/track/" + testId + "/", URI{KindTrack, testId}},
+		{"https://open.spotify.com/intl-de/artist/" + testId + "?si=1f2e3d&nd=1", URI{KindArtist, testId}},
+		{"http://play.spotify.com/episode/" + testId + "#t=10", URI{KindEpisode, testId}},
+		{"spotify:user:someone:playlist:" + testId, URI{KindPlaylist, testId}},
+		{"https://open.spotify.com/user/someone/playlist/" + testId, URI{KindPlaylist, testId}},
+		{"https://open.spotify.com/user/some.one_1", URI{KindUser, "some.one_1"}},
+		{"spotify:chapter:" + testId, URI{KindChapter, testId}},
+	}
+	for _, test := range tests {
+		uri, err := Parse(test.input)
+		if err != nil {
+			t.Errorf("Unexpected error for %q: %v", test.input, err)
+			continue
+		}
+		if uri != test.expected {
+			t.Errorf("Expected %v for %q, got %v", test.expected, test.input, uri)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, input := range []string{
+		"",
+		testId,
+		"spotify:track",
+		"spotify:song:" + testId,
+		"spotify:track:" + testId[:21],
+		"spotify:track:" + testId[:21] + "!",
+		"https://example.com/track/" + testId,
+		"ftp://open.spotify.com/track/" + testId,
+		"https://open.spotify.com/track/" + testId + "/extra",
+		"https://open.spotify.com/",
+	} {
+		_, err := Parse(input)
+		if !errors.Is(err, ErrInvalid) {
+			t.Errorf("Expected %v for %q, got %v", ErrInvalid, input, err)
+		}
+	}
+}
+
+func TestParseID(t *testing.T) {
+	id, err := ParseID(testId)
+	if err != nil || id != testId {
+		t.Errorf("Expected %s, got %s %v", testId, id, err)
+	}
+	if _, err := ParseID("spotify:track:" + testId); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Expected %v, got %v", ErrInvalid, err)
+	}
+	if _, err := New("podcast", testId); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Expected %v, got %v", ErrInvalid, err)
+	}
+}
+
+func TestURIForms(t *testing.T) {
+	uri := MustParse("https://open.spotify.com/intl-fr/track/" + testId + "?si=abc")
+	if uri.String() != "spotify:track:"+testId {
+		t.Errorf("Unexpected URI: %s", uri.String())
+	}
+	if uri.URL() != "https://open.spotify.com/track/"+testId {
+		t.Errorf("Unexpected URL: %s", uri.URL())
+	}
+
+	other := MustParse("spotify:episode:4rOoJ6Egrf8K2IrywzwOMk")
+	if ids := IDs(uri, other); !reflect.DeepEqual(ids, []string{testId, "4rOoJ6Egrf8K2IrywzwOMk"}) {
+		t.Errorf("Unexpected ids: %v", ids)
+	}
+	if strs := Strings(uri, other); !reflect.DeepEqual(strs, []string{uri.String(), other.String()}) {
+		t.Errorf("Unexpected URIs: %v", strs)
+	}
+
+	var decoded struct {
+		Item URI `json:"item"`
+	}
+	err := json.Unmarshal([]byte(`{"item":"https://open.spotify.com/track/`+testId+`"}`), &decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"item":"spotify:track:`+testId+`"}` {
+		t.Errorf("Unexpected JSON: %s", data)
+	}
+}